@@ -28,6 +28,26 @@ func BenchmarkParallel(b *testing.B) {
 	numbers.Drain()
 }
 
+func cpuBound(data stream.T) stream.T {
+	sum := 0
+	for i := 0; i < 100000; i++ {
+		sum += i % (data.(int) + 1)
+	}
+	return sum
+}
+
+func BenchmarkMap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		rivers.FromRange(1, 1000).Map(cpuBound).Drain()
+	}
+}
+
+func BenchmarkMapParallel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		rivers.FromRange(1, 1000).MapParallel(runtime.NumCPU(), cpuBound).Drain()
+	}
+}
+
 func TestErrorInjection(t *testing.T) {
 	err := errors.New("Error Injected")
 
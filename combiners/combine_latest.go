@@ -0,0 +1,122 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type update struct {
+	index int
+	data  stream.T
+}
+
+type combineLatest struct {
+	context stream.Context
+	fn      func(latest ...stream.T) stream.T
+}
+
+// CombineLatest emits the result of fn applied to the most recent item of
+// every stream whenever any of them produces a new item. Nothing is emitted
+// until every stream has produced at least one item, and the combined
+// stream closes once all streams are done.
+func CombineLatest(fn func(latest ...stream.T) stream.T) stream.Combiner {
+	return &combineLatest{
+		fn: fn,
+	}
+}
+
+func (combiner *combineLatest) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *combineLatest) Combine(in ...stream.Readable) stream.Readable {
+	capacity := 0
+	for _, r := range in {
+		capacity += r.Capacity()
+	}
+
+	var wg sync.WaitGroup
+	updates := make(chan update, capacity)
+	reader, writer := stream.New(capacity)
+
+	for i, r := range in {
+		wg.Add(1)
+		go func(index int, r stream.Readable) {
+			defer wg.Done()
+			defer combiner.context.Recover()
+
+			for {
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case <-time.After(combiner.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-r:
+					if !more {
+						return
+					}
+					select {
+					case <-combiner.context.Failure():
+						return
+					case <-combiner.context.Done():
+						return
+					case updates <- update{index, data}:
+					}
+				}
+			}
+		}(i, r)
+	}
+
+	go func() {
+		defer close(updates)
+		wg.Wait()
+	}()
+
+	go func() {
+		defer close(writer)
+		defer combiner.context.Recover()
+
+		latest := make([]stream.T, len(in))
+		seen := make([]bool, len(in))
+		seenCount := 0
+
+		for {
+			select {
+			case <-combiner.context.Failure():
+				return
+			case <-combiner.context.Done():
+				return
+			case u, more := <-updates:
+				if !more {
+					return
+				}
+
+				if !seen[u.index] {
+					seen[u.index] = true
+					seenCount++
+				}
+				latest[u.index] = u.data
+
+				if seenCount < len(in) {
+					continue
+				}
+
+				snapshot := make([]stream.T, len(latest))
+				copy(snapshot, latest)
+
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case writer <- combiner.fn(snapshot...):
+				}
+			}
+		}
+	}()
+
+	return reader
+}
@@ -0,0 +1,84 @@
+package combiners_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestCombineLatest(t *testing.T) {
+	sum := func(latest ...stream.T) stream.T {
+		sum := 0
+		for _, item := range latest {
+			sum += item.(int)
+		}
+		return sum
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a couple of streams", func() {
+			in1, out1 := stream.New(2)
+			in2, out2 := stream.New(2)
+
+			combiner := combiners.CombineLatest(sum)
+			combiner.Attach(context)
+			combined := combiner.Combine(in1, in2)
+
+			Convey("When items arrive on every stream", func() {
+				out1 <- 1
+				out1 <- 2
+				time.Sleep(10 * time.Millisecond)
+				out2 <- 10
+				time.Sleep(10 * time.Millisecond)
+				out1 <- 3
+				close(out1)
+				time.Sleep(10 * time.Millisecond)
+				out2 <- 20
+				close(out2)
+
+				Convey("Then the latest items are combined once every stream produced one", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{12, 13, 23})
+				})
+			})
+
+			Convey("When one of the streams never produces an item", func() {
+				out1 <- 1
+				out1 <- 2
+				close(out1)
+				close(out2)
+
+				Convey("Then nothing is emitted", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When the context is closed gracefully mid-stream", func() {
+				out1 <- 1
+				out2 <- 10
+				So(<-combined, ShouldEqual, 11)
+				context.Close(nil)
+
+				Convey("Then the combined stream is closed even though the streams are not", func() {
+					combined.ReadAll()
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When the context fails", func() {
+				failure := errors.New("upstream failure")
+				context.Close(failure)
+
+				Convey("Then the combined stream is closed", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+		})
+	})
+}
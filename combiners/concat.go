@@ -0,0 +1,76 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type concat struct {
+	context stream.Context
+}
+
+// Concat forwards every item of each stream before moving on to the next
+// one, so items keep their order across streams. Streams not reached yet
+// are left untouched if the context is closed.
+func Concat() stream.Combiner {
+	return &concat{}
+}
+
+func (combiner *concat) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *concat) Combine(in ...stream.Readable) stream.Readable {
+	capacity := 0
+	for _, r := range in {
+		if r.Capacity() > capacity {
+			capacity = r.Capacity()
+		}
+	}
+
+	reader, writer := stream.New(capacity)
+	emitter := stream.NewEmitter(combiner.context, writer)
+
+	go func() {
+		defer close(writer)
+		defer combiner.context.Recover()
+
+		// stopped gives the context priority over streams ready to be read,
+		// so a failure is never followed by items of the next stream
+		stopped := func() bool {
+			select {
+			case <-combiner.context.Failure():
+				return true
+			case <-combiner.context.Done():
+				return true
+			default:
+				return false
+			}
+		}
+
+		for _, r := range in {
+			for drained := false; !drained; {
+				if stopped() {
+					return
+				}
+
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case <-time.After(combiner.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-r:
+					if !more {
+						drained = true
+						continue
+					}
+					emitter.Emit(data)
+				}
+			}
+		}
+	}()
+
+	return reader
+}
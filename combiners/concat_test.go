@@ -0,0 +1,64 @@
+package combiners_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a couple of streams", func() {
+			in1, out1 := stream.New(2)
+			in2, out2 := stream.New(2)
+			out2 <- 3
+			out2 <- 4
+			close(out2)
+
+			Convey("When I concatenate the streams", func() {
+				out1 <- 1
+				out1 <- 2
+				close(out1)
+
+				combiner := combiners.Concat()
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then items are forwarded stream by stream", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4})
+				})
+			})
+
+			Convey("When the first stream fails", func() {
+				combiner := combiners.Concat()
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				failure := errors.New("upstream failure")
+				context.Close(failure)
+				close(out1)
+
+				Convey("Then the following streams are not consumed", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+					So(in2.ReadAll(), ShouldResemble, []stream.T{3, 4})
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+		})
+
+		Convey("When I concatenate no streams", func() {
+			combiner := combiners.Concat()
+			combiner.Attach(context)
+			combined := combiner.Combine()
+
+			Convey("Then the combined stream is closed right away", func() {
+				So(combined.ReadAll(), ShouldBeEmpty)
+			})
+		})
+	})
+}
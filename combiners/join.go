@@ -0,0 +1,147 @@
+package combiners
+
+import (
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+var ErrInvalidJoin = errors.New("Join requires exactly two streams")
+
+type join struct {
+	context    stream.Context
+	key        stream.MapFn
+	combine    func(a, b stream.T) stream.T
+	maxPending int
+	evicted    stream.Writable
+}
+
+// Join inner joins two streams, emitting combine(a, b) for items a and b
+// sharing the same key, a coming from the first stream and b from the
+// second. Each item matches at most once, in arrival order. Unmatched items
+// are buffered until a match arrives or the other stream closes, so keys
+// that never match grow memory unbounded unless MaxPending is set. Joining
+// other than two streams closes the context with ErrInvalidJoin.
+func Join(key stream.MapFn, combine func(a, b stream.T) stream.T) *join {
+	return &join{
+		key:     key,
+		combine: combine,
+	}
+}
+
+// MaxPending limits the unmatched items buffered for each stream, evicting
+// the oldest one once the limit is exceeded.
+func (combiner *join) MaxPending(n int) *join {
+	combiner.maxPending = n
+	return combiner
+}
+
+// EvictTo sends items evicted by MaxPending to evicted. The stream is not
+// closed by the combiner and must be drained for the join to make progress.
+func (combiner *join) EvictTo(evicted stream.Writable) *join {
+	combiner.evicted = evicted
+	return combiner
+}
+
+func (combiner *join) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *join) Combine(in ...stream.Readable) stream.Readable {
+	capacity := 0
+	for _, r := range in {
+		if r.Capacity() > capacity {
+			capacity = r.Capacity()
+		}
+	}
+
+	reader, writer := stream.New(capacity)
+	emitter := stream.NewEmitter(combiner.context, writer)
+
+	go func() {
+		defer close(writer)
+		defer combiner.context.Recover()
+
+		if len(in) != 2 {
+			panic(ErrInvalidJoin)
+		}
+
+		type pending struct {
+			key  stream.T
+			item stream.T
+		}
+
+		var lefts, rights []pending
+
+		// match removes and returns the oldest pending item with key
+		match := func(entries []pending, key stream.T) ([]pending, stream.T, bool) {
+			for i, entry := range entries {
+				if entry.key == key {
+					return append(entries[:i], entries[i+1:]...), entry.item, true
+				}
+			}
+			return entries, nil, false
+		}
+
+		buffer := func(entries []pending, entry pending) []pending {
+			entries = append(entries, entry)
+			if combiner.maxPending <= 0 || len(entries) <= combiner.maxPending {
+				return entries
+			}
+
+			if combiner.evicted != nil {
+				select {
+				case <-combiner.context.Failure():
+				case <-combiner.context.Done():
+				case combiner.evicted <- entries[0].item:
+				}
+			}
+			return entries[1:]
+		}
+
+		left, right := in[0], in[1]
+		for left != nil || right != nil {
+			select {
+			case <-combiner.context.Failure():
+				return
+			case <-combiner.context.Done():
+				return
+			case <-time.After(combiner.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-left:
+				if !more {
+					// rights are only buffered to match upcoming lefts
+					left = nil
+					rights = nil
+					continue
+				}
+
+				key := combiner.key(data)
+				var matched stream.T
+				var found bool
+				if rights, matched, found = match(rights, key); found {
+					emitter.Emit(combiner.combine(data, matched))
+				} else if right != nil {
+					lefts = buffer(lefts, pending{key, data})
+				}
+			case data, more := <-right:
+				if !more {
+					right = nil
+					lefts = nil
+					continue
+				}
+
+				key := combiner.key(data)
+				var matched stream.T
+				var found bool
+				if lefts, matched, found = match(lefts, key); found {
+					emitter.Emit(combiner.combine(matched, data))
+				} else if left != nil {
+					rights = buffer(rights, pending{key, data})
+				}
+			}
+		}
+	}()
+
+	return reader
+}
@@ -0,0 +1,108 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"sort"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	type order struct {
+		UserID int
+		Total  int
+	}
+
+	key := func(data stream.T) stream.T {
+		switch item := data.(type) {
+		case user:
+			return item.ID
+		case order:
+			return item.UserID
+		}
+		return nil
+	}
+
+	combine := func(a, b stream.T) stream.T {
+		return []stream.T{a.(user).Name, b.(order).Total}
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a couple of streams", func() {
+			users, usersOut := stream.New(3)
+			usersOut <- user{1, "diego"}
+			usersOut <- user{2, "borges"}
+			usersOut <- user{3, "unmatched"}
+			close(usersOut)
+
+			orders, ordersOut := stream.New(3)
+			ordersOut <- order{2, 20}
+			ordersOut <- order{1, 10}
+			ordersOut <- order{4, 40}
+			close(ordersOut)
+
+			Convey("When I join the streams", func() {
+				combiner := combiners.Join(key, combine)
+				combiner.Attach(context)
+				joined := combiner.Combine(users, orders)
+
+				Convey("Then only items with matching keys are combined", func() {
+					items := joined.ReadAll()
+					sort.Slice(items, func(i, j int) bool {
+						return items[i].([]stream.T)[1].(int) < items[j].([]stream.T)[1].(int)
+					})
+					So(items, ShouldResemble, []stream.T{
+						[]stream.T{"diego", 10},
+						[]stream.T{"borges", 20},
+					})
+				})
+			})
+
+			Convey("When I join a single stream", func() {
+				combiner := combiners.Join(key, combine)
+				combiner.Attach(context)
+				joined := combiner.Combine(users)
+
+				Convey("Then the context is closed with an error", func() {
+					So(joined.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, combiners.ErrInvalidJoin)
+				})
+			})
+		})
+
+		Convey("And a couple of unbuffered streams", func() {
+			users, usersOut := stream.New(0)
+			orders, ordersOut := stream.New(0)
+
+			Convey("When I limit the pending items", func() {
+				evicted, evictedOut := stream.New(2)
+				combiner := combiners.Join(key, combine).MaxPending(1).EvictTo(evictedOut)
+				combiner.Attach(context)
+				joined := combiner.Combine(users, orders)
+
+				// each send completes only once the join received it, so
+				// items are joined in this exact order
+				usersOut <- user{1, "diego"}
+				usersOut <- user{2, "borges"}
+				ordersOut <- order{2, 20}
+				close(usersOut)
+				close(ordersOut)
+
+				Convey("Then the oldest pending item is evicted", func() {
+					So(joined.ReadAll(), ShouldResemble, []stream.T{[]stream.T{"borges", 20}})
+					close(evictedOut)
+					So(evicted.ReadAll(), ShouldResemble, []stream.T{user{1, "diego"}})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type merge struct {
+	context stream.Context
+}
+
+// Merge forwards items from all streams as they arrive, so their
+// interleaving is not deterministic. The merged stream closes once every
+// stream is done, or as soon as the context fails: streams share the
+// combiner's context, so a failure in any of them closes the merged
+// stream. Items already emitted are still forwarded after a graceful
+// stop, as stages stopping upstream may have emitted them right before.
+func Merge() stream.Combiner {
+	return &merge{}
+}
+
+func (combiner *merge) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *merge) Combine(in ...stream.Readable) stream.Readable {
+	capacity := 0
+	for _, r := range in {
+		capacity += r.Capacity()
+	}
+
+	var wg sync.WaitGroup
+	reader, writer := stream.New(capacity)
+
+	for _, r := range in {
+		wg.Add(1)
+		go func(r stream.Readable) {
+			defer wg.Done()
+			defer combiner.context.Recover()
+
+			for {
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-time.After(combiner.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-r:
+					if !more {
+						return
+					}
+					select {
+					case <-combiner.context.Failure():
+						return
+					case writer <- data:
+					}
+				}
+			}
+		}(r)
+	}
+
+	go func() {
+		defer close(writer)
+		wg.Wait()
+	}()
+
+	return reader
+}
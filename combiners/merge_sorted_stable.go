@@ -0,0 +1,95 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type mergeSortedStable struct {
+	context stream.Context
+	less    stream.SortByFn
+}
+
+// MergeSortedStable merges streams already sorted by less into a single
+// sorted stream. Equal items are emitted in the order their streams were
+// given, so the merged stream is the same across runs.
+func MergeSortedStable(less stream.SortByFn) stream.Combiner {
+	return &mergeSortedStable{
+		less: less,
+	}
+}
+
+func (combiner *mergeSortedStable) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *mergeSortedStable) Combine(in ...stream.Readable) stream.Readable {
+	capacity := func(in ...stream.Readable) int {
+		capacity := 0
+		for _, r := range in {
+			capacity += r.Capacity()
+		}
+		return capacity
+	}
+
+	reader, writer := stream.New(capacity(in...))
+
+	go func() {
+		defer close(writer)
+		defer combiner.context.Recover()
+
+		heads := make([]stream.T, len(in))
+		pending := make([]bool, len(in))
+
+		// next reads the following head of the i-th stream, returning
+		// false if the context fails while waiting for it
+		next := func(i int) bool {
+			select {
+			case <-combiner.context.Failure():
+				return false
+			default:
+			}
+
+			select {
+			case <-combiner.context.Failure():
+				return false
+			case <-time.After(combiner.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in[i]:
+				heads[i], pending[i] = data, more
+				return true
+			}
+		}
+
+		for i := range in {
+			if !next(i) {
+				return
+			}
+		}
+
+		for {
+			min := -1
+			for i := range in {
+				if pending[i] && (min < 0 || combiner.less(heads[i], heads[min])) {
+					min = i
+				}
+			}
+
+			if min < 0 {
+				return
+			}
+
+			select {
+			case <-combiner.context.Failure():
+				return
+			case writer <- heads[min]:
+			}
+
+			if !next(min) {
+				return
+			}
+		}
+	}()
+
+	return reader
+}
@@ -0,0 +1,70 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type keyed struct {
+	key    int
+	source string
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	less := func(a, b stream.T) bool { return a.(keyed).key < b.(keyed).key }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And sorted streams of unequal lengths sharing keys", func() {
+			in1, out1 := stream.New(3)
+			out1 <- keyed{1, "a"}
+			out1 <- keyed{3, "a"}
+			out1 <- keyed{5, "a"}
+			close(out1)
+
+			in2, out2 := stream.New(2)
+			out2 <- keyed{1, "b"}
+			out2 <- keyed{3, "b"}
+			close(out2)
+
+			in3, out3 := stream.New(1)
+			out3 <- keyed{2, "c"}
+			close(out3)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.MergeSortedStable(less)
+				combiner.Attach(context)
+				combined := combiner.Combine(in2, in1, in3)
+
+				Convey("Then items are merged in order breaking ties by stream position", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{
+						keyed{1, "b"},
+						keyed{1, "a"},
+						keyed{2, "c"},
+						keyed{3, "b"},
+						keyed{3, "a"},
+						keyed{5, "a"},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.MergeSortedStable(less)
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2, in3)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package combiners_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a couple of streams", func() {
+			in1, out1 := stream.New(2)
+			out1 <- 1
+			out1 <- 2
+			close(out1)
+
+			in2, out2 := stream.New(2)
+			out2 <- 3
+			out2 <- 4
+
+			Convey("When I merge the streams", func() {
+				close(out2)
+				combiner := combiners.Merge()
+				combiner.Attach(context)
+				merged := combiner.Combine(in1, in2)
+
+				Convey("Then items from every stream are forwarded", func() {
+					items := merged.ReadAll()
+					So(items, ShouldHaveLength, 4)
+					So(items, ShouldContain, 1)
+					So(items, ShouldContain, 2)
+					So(items, ShouldContain, 3)
+					So(items, ShouldContain, 4)
+				})
+			})
+
+			Convey("When one of the streams fails", func() {
+				combiner := combiners.Merge()
+				combiner.Attach(context)
+				merged := combiner.Combine(in1, in2)
+				failure := errors.New("upstream failure")
+				context.Close(failure)
+
+				Convey("Then the merged stream is closed with the error", func() {
+					merged.ReadAll()
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+		})
+	})
+}
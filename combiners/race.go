@@ -0,0 +1,120 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type race struct {
+	context stream.Context
+}
+
+// Race forwards all items of whichever stream emits first. Streams share
+// the combiner's context and cannot be cancelled on their own, so losing
+// streams are drained and their items discarded, letting their upstream
+// stages finish without blocking.
+func Race() stream.Combiner {
+	return &race{}
+}
+
+// Amb is an alias of Race
+func Amb() stream.Combiner {
+	return Race()
+}
+
+func (combiner *race) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *race) Combine(in ...stream.Readable) stream.Readable {
+	capacity := 0
+	for _, r := range in {
+		if r.Capacity() > capacity {
+			capacity = r.Capacity()
+		}
+	}
+
+	reader, writer := stream.New(capacity)
+
+	if len(in) == 0 {
+		close(writer)
+		return reader
+	}
+
+	var mutex sync.Mutex
+	winner, finished := -1, -2
+	pending := len(in)
+
+	// finish closes the combined stream if the given stream won the race
+	// or if no stream won it yet, in which case the race is over.
+	finish := func(index int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if winner == -1 {
+			winner = finished
+			close(writer)
+		} else if winner == index {
+			close(writer)
+		}
+	}
+
+	for i, r := range in {
+		go func(index int, r stream.Readable) {
+			defer combiner.context.Recover()
+
+			won := false
+			for {
+				select {
+				case <-combiner.context.Failure():
+					finish(index)
+					return
+				case <-combiner.context.Done():
+					finish(index)
+					return
+				case <-time.After(combiner.context.Deadline()):
+					finish(index)
+					panic(stream.Timeout)
+				case data, more := <-r:
+					if !more {
+						mutex.Lock()
+						pending--
+						last := pending == 0
+						mutex.Unlock()
+
+						if won || last {
+							finish(index)
+						}
+						return
+					}
+
+					if !won {
+						mutex.Lock()
+						if winner == -1 {
+							winner = index
+						}
+						won = winner == index
+						mutex.Unlock()
+					}
+
+					if !won {
+						continue
+					}
+
+					select {
+					case <-combiner.context.Failure():
+						finish(index)
+						return
+					case <-combiner.context.Done():
+						finish(index)
+						return
+					case writer <- data:
+					}
+				}
+			}
+		}(i, r)
+	}
+
+	return reader
+}
@@ -0,0 +1,81 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestRace(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a couple of streams", func() {
+			slow, slowOut := stream.New(0)
+			fast, fastOut := stream.New(2)
+
+			combiner := combiners.Race()
+			combiner.Attach(context)
+			combined := combiner.Combine(slow, fast)
+
+			Convey("When one of the streams emits first", func() {
+				fastOut <- 1
+				time.Sleep(10 * time.Millisecond)
+
+				// slow is unbuffered, so its items are only sent as long as
+				// the combiner keeps reading them
+				drained := make(chan bool)
+				go func() {
+					defer close(drained)
+					for i := 10; i < 15; i++ {
+						slowOut <- i
+					}
+					close(slowOut)
+				}()
+
+				fastOut <- 2
+				close(fastOut)
+
+				Convey("Then only items from that stream are forwarded", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{1, 2})
+
+					Convey("And the losing stream is drained", func() {
+						So(<-drained, ShouldBeFalse)
+					})
+				})
+			})
+
+			Convey("When the context is closed gracefully mid-stream", func() {
+				fastOut <- 1
+				So(<-combined, ShouldEqual, 1)
+				context.Close(nil)
+
+				Convey("Then the combined stream is closed even though the streams are not", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When no stream emits", func() {
+				close(slowOut)
+				close(fastOut)
+
+				Convey("Then the combined stream is closed", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When I race no streams", func() {
+			combiner := combiners.Amb()
+			combiner.Attach(context)
+
+			Convey("Then the combined stream is closed right away", func() {
+				So(combiner.Combine().ReadAll(), ShouldBeEmpty)
+			})
+		})
+	})
+}
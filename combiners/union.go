@@ -0,0 +1,111 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type union struct {
+	context stream.Context
+	key     stream.MapFn
+}
+
+// Union merges the given streams emitting only the first occurrence of
+// each key. Every key seen is kept in memory, which grows unbounded on
+// infinite streams with an unbounded key space.
+func Union(key stream.MapFn) stream.Combiner {
+	return &union{
+		key: key,
+	}
+}
+
+func (combiner *union) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *union) Combine(in ...stream.Readable) stream.Readable {
+	capacity := func(in ...stream.Readable) int {
+		capacity := 0
+		for _, r := range in {
+			capacity += r.Capacity()
+		}
+		return capacity
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	seen := make(map[stream.T]bool)
+	reader, writer := stream.New(capacity(in...))
+
+	firstOccurrence := func(data stream.T) bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		key := combiner.key(data)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+
+	// stopped gives the context priority over streams ready to be read and
+	// over room in the combined stream
+	stopped := func() bool {
+		select {
+		case <-combiner.context.Failure():
+			return true
+		case <-combiner.context.Done():
+			return true
+		default:
+			return false
+		}
+	}
+
+	for _, r := range in {
+		wg.Add(1)
+		go func(r stream.Readable) {
+			defer wg.Done()
+			defer combiner.context.Recover()
+
+			for {
+				if stopped() {
+					return
+				}
+
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case <-time.After(combiner.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-r:
+					if !more {
+						return
+					}
+
+					if stopped() || !firstOccurrence(data) {
+						continue
+					}
+
+					select {
+					case <-combiner.context.Failure():
+						return
+					case <-combiner.context.Done():
+						return
+					case writer <- data:
+					}
+				}
+			}
+		}(r)
+	}
+
+	go func() {
+		defer close(writer)
+		wg.Wait()
+	}()
+
+	return reader
+}
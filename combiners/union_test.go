@@ -0,0 +1,85 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestUnion(t *testing.T) {
+	identity := func(d stream.T) stream.T { return d }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in1, out1 := stream.New(3)
+			out1 <- 1
+			out1 <- 2
+			out1 <- 2
+			close(out1)
+
+			in2, out2 := stream.New(2)
+			out2 <- 2
+			out2 <- 3
+			close(out2)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.Union(identity)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then each distinct item is emitted only once", func() {
+					items := combined.ReadAll()
+					So(len(items), ShouldEqual, 3)
+					So(items, ShouldContain, 1)
+					So(items, ShouldContain, 2)
+					So(items, ShouldContain, 3)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.Union(identity)
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And an unbuffered stream that never closes", func() {
+			in, out := stream.New(0)
+			go func() {
+				for i := 0; ; i++ {
+					select {
+					case <-context.Done():
+						return
+					case out <- i:
+					}
+				}
+			}()
+
+			Convey("When I close the context while the union is sending", func() {
+				combiner := combiners.Union(identity)
+				combiner.Attach(context)
+				combined := combiner.Combine(in)
+
+				So(<-combined, ShouldEqual, 0)
+				context.Close(nil)
+
+				Convey("Then the combined stream is closed", func() {
+					combined.ReadAll()
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,100 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type withLatestFrom struct {
+	context stream.Context
+}
+
+// WithLatestFrom treats the first stream as the primary one, emitting for
+// each of its items a []stream.T holding the item followed by the latest
+// item seen on every other stream. Items from the other streams only update
+// that state, and primary items arriving before every other stream produced
+// an item are dropped. The combined stream closes along with the primary
+// stream.
+func WithLatestFrom() stream.Combiner {
+	return &withLatestFrom{}
+}
+
+func (combiner *withLatestFrom) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *withLatestFrom) Combine(in ...stream.Readable) stream.Readable {
+	if len(in) == 0 {
+		reader, writer := stream.New(0)
+		close(writer)
+		return reader
+	}
+
+	primary, others := in[0], in[1:]
+
+	var mutex sync.Mutex
+	latest := make([]stream.T, len(others))
+	seen := make([]bool, len(others))
+	seenCount := 0
+
+	for i, r := range others {
+		go func(index int, r stream.Readable) {
+			defer combiner.context.Recover()
+
+			for {
+				select {
+				case <-combiner.context.Failure():
+					return
+				case data, more := <-r:
+					if !more {
+						return
+					}
+					mutex.Lock()
+					if !seen[index] {
+						seen[index] = true
+						seenCount++
+					}
+					latest[index] = data
+					mutex.Unlock()
+				}
+			}
+		}(i, r)
+	}
+
+	reader, writer := stream.New(primary.Capacity())
+
+	go func() {
+		defer close(writer)
+		defer combiner.context.Recover()
+
+		for {
+			select {
+			case <-combiner.context.Failure():
+				return
+			case <-time.After(combiner.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-primary:
+				if !more {
+					return
+				}
+
+				mutex.Lock()
+				if seenCount < len(others) {
+					mutex.Unlock()
+					continue
+				}
+				combined := append([]stream.T{data}, latest...)
+				mutex.Unlock()
+
+				select {
+				case <-combiner.context.Failure():
+					return
+				case writer <- combined:
+				}
+			}
+		}
+	}()
+
+	return reader
+}
@@ -0,0 +1,67 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestWithLatestFrom(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a primary and a secondary stream", func() {
+			primary, primaryOut := stream.New(2)
+			other, otherOut := stream.New(2)
+
+			combiner := combiners.WithLatestFrom()
+			combiner.Attach(context)
+			combined := combiner.Combine(primary, other)
+
+			Convey("When items arrive on both streams", func() {
+				primaryOut <- 1
+				time.Sleep(10 * time.Millisecond)
+				otherOut <- "a"
+				time.Sleep(10 * time.Millisecond)
+				primaryOut <- 2
+				time.Sleep(10 * time.Millisecond)
+				otherOut <- "b"
+				otherOut <- "c"
+				close(otherOut)
+				time.Sleep(10 * time.Millisecond)
+				primaryOut <- 3
+				close(primaryOut)
+
+				Convey("Then primary items are combined with the latest secondary item", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{2, "a"},
+						[]stream.T{3, "c"},
+					})
+				})
+			})
+
+			Convey("When the secondary stream never produces an item", func() {
+				primaryOut <- 1
+				primaryOut <- 2
+				close(primaryOut)
+				close(otherOut)
+
+				Convey("Then primary items are dropped", func() {
+					So(combined.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When I combine no streams", func() {
+			combiner := combiners.WithLatestFrom()
+			combiner.Attach(context)
+
+			Convey("Then the combined stream is closed right away", func() {
+				So(combiner.Combine().ReadAll(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -9,6 +9,9 @@ type zip struct {
 	context stream.Context
 }
 
+// Zip interleaves items of all streams, one from each stream per round,
+// until every stream is drained. See ZipWith for combining each round into
+// a single value.
 func Zip() stream.Combiner {
 	return &zip{}
 }
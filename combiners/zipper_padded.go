@@ -0,0 +1,88 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type zipPadded struct {
+	context stream.Context
+	pad     stream.T
+}
+
+// ZipPadded emits a []stream.T holding the n-th item of every stream,
+// substituting pad for streams already closed. It runs until the longest
+// stream is exhausted.
+func ZipPadded(pad stream.T) stream.Combiner {
+	return &zipPadded{
+		pad: pad,
+	}
+}
+
+func (combiner *zipPadded) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *zipPadded) Combine(in ...stream.Readable) stream.Readable {
+	capacity := 0
+	for _, r := range in {
+		if r.Capacity() > capacity {
+			capacity = r.Capacity()
+		}
+	}
+
+	reader, writer := stream.New(capacity)
+
+	go func() {
+		defer combiner.context.Recover()
+		defer close(writer)
+
+		closed := make([]bool, len(in))
+		closedCount := 0
+
+		for closedCount < len(in) {
+			select {
+			case <-combiner.context.Failure():
+				return
+			case <-time.After(combiner.context.Deadline()):
+				panic(stream.Timeout)
+			default:
+				items := make([]stream.T, len(in))
+				for i, readable := range in {
+					items[i] = combiner.pad
+					if closed[i] {
+						continue
+					}
+
+					select {
+					case <-combiner.context.Failure():
+						return
+					case <-combiner.context.Done():
+						return
+					case data, more := <-readable:
+						if !more {
+							closed[i] = true
+							closedCount++
+							continue
+						}
+						items[i] = data
+					}
+				}
+
+				if closedCount == len(in) {
+					return
+				}
+
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case writer <- items:
+				}
+			}
+		}
+	}()
+
+	return reader
+}
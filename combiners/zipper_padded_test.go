@@ -0,0 +1,76 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestZipperPadded(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in1, out1 := stream.New(2)
+			out1 <- 1
+			out1 <- 2
+			close(out1)
+
+			in2, out2 := stream.New(4)
+			out2 <- 3
+			out2 <- 4
+			out2 <- 5
+			out2 <- 6
+			close(out2)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.ZipPadded(0)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then the shortest stream is padded up to the longest one", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{1, 3},
+						[]stream.T{2, 4},
+						[]stream.T{0, 5},
+						[]stream.T{0, 6},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.ZipPadded(0)
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And streams that are never closed", func() {
+			in1, out1 := stream.New(0)
+			in2, out2 := stream.New(0)
+
+			Convey("When I close the context while the combined stream is not read", func() {
+				combiner := combiners.ZipPadded(0)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+				out1 <- 1
+				out2 <- 2
+				context.Close(nil)
+
+				Convey("Then the combined stream is closed", func() {
+					So(len(combined.ReadAll()), ShouldBeLessThanOrEqualTo, 1)
+				})
+			})
+		})
+	})
+}
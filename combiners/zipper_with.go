@@ -0,0 +1,14 @@
+package combiners
+
+import "github.com/drborges/rivers/stream"
+
+// ZipWith reads one item from every stream and emits the result of fn
+// applied to that tuple. Unlike Zip, which interleaves items of all streams
+// until every one of them is drained, ZipWith emits a single value per round
+// and stops at the shortest stream, telling upstream stages to shutdown
+// without errors, see ZipWithIndex.
+func ZipWith(fn func(items ...stream.T) stream.T) stream.Combiner {
+	return ZipWithIndex(func(_ int, items ...stream.T) stream.T {
+		return fn(items...)
+	})
+}
@@ -0,0 +1,80 @@
+package combiners
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type zipWithIndex struct {
+	context stream.Context
+	fn      func(index int, items ...stream.T) stream.T
+}
+
+// ZipWithIndex combines the n-th item of every stream along with n, the
+// zero based round. It stops at the shortest stream, telling upstream
+// stages to shutdown without errors.
+func ZipWithIndex(fn func(index int, items ...stream.T) stream.T) stream.Combiner {
+	return &zipWithIndex{
+		fn: fn,
+	}
+}
+
+func (combiner *zipWithIndex) Attach(context stream.Context) {
+	combiner.context = context
+}
+
+func (combiner *zipWithIndex) Combine(in ...stream.Readable) stream.Readable {
+	capacity := 0
+	for i, r := range in {
+		if i == 0 || r.Capacity() < capacity {
+			capacity = r.Capacity()
+		}
+	}
+
+	reader, writer := stream.New(capacity)
+
+	if len(in) == 0 {
+		close(writer)
+		return reader
+	}
+
+	go func() {
+		defer combiner.context.Recover()
+		defer close(writer)
+
+		for index := 0; ; index++ {
+			select {
+			case <-combiner.context.Failure():
+				return
+			case <-time.After(combiner.context.Deadline()):
+				panic(stream.Timeout)
+			default:
+				items := make([]stream.T, len(in))
+				for i, readable := range in {
+					select {
+					case <-combiner.context.Failure():
+						return
+					case <-combiner.context.Done():
+						return
+					case data, more := <-readable:
+						if !more {
+							combiner.context.Close(nil)
+							return
+						}
+						items[i] = data
+					}
+				}
+
+				select {
+				case <-combiner.context.Failure():
+					return
+				case <-combiner.context.Done():
+					return
+				case writer <- combiner.fn(index, items...):
+				}
+			}
+		}
+	}()
+
+	return reader
+}
@@ -0,0 +1,88 @@
+package combiners_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/combiners"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestZipperWith(t *testing.T) {
+	sum := func(items ...stream.T) stream.T {
+		sum := 0
+		for _, item := range items {
+			sum += item.(int)
+		}
+		return sum
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in1, out1 := stream.New(3)
+			out1 <- 1
+			out1 <- 2
+			out1 <- 3
+			close(out1)
+
+			in2, out2 := stream.New(2)
+			out2 <- 4
+			out2 <- 5
+			close(out2)
+
+			Convey("When I apply the combiner to the streams", func() {
+				combiner := combiners.ZipWith(sum)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+
+				Convey("Then tuples are combined up to the shortest stream", func() {
+					So(combined.ReadAll(), ShouldResemble, []stream.T{5, 7})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the combiner to the streams", func() {
+					combiner := combiners.ZipWith(sum)
+					combiner.Attach(context)
+					combined := combiner.Combine(in1, in2)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(combined.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And streams that are never closed", func() {
+			in1, out1 := stream.New(0)
+			in2, out2 := stream.New(0)
+
+			Convey("When I close the context while the combined stream is not read", func() {
+				combiner := combiners.ZipWith(sum)
+				combiner.Attach(context)
+				combined := combiner.Combine(in1, in2)
+				out1 <- 1
+				out2 <- 2
+				context.Close(nil)
+
+				Convey("Then the combined stream is closed", func() {
+					So(len(combined.ReadAll()), ShouldBeLessThanOrEqualTo, 1)
+				})
+			})
+		})
+
+		Convey("When I zip no streams", func() {
+			combiner := combiners.ZipWith(sum)
+			combiner.Attach(context)
+
+			Convey("Then the combined stream is closed right away", func() {
+				So(combiner.Combine().ReadAll(), ShouldBeEmpty)
+			})
+		})
+	})
+}
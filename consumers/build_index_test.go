@@ -0,0 +1,40 @@
+package consumers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestBuildIndex(t *testing.T) {
+	words := func(data stream.T) []string { return strings.Fields(data.(string)) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- "hello world"
+			out <- "hello rivers"
+			close(out)
+
+			Convey("When I apply the index builder consumer", func() {
+				index := make(map[string][]stream.T)
+				consumer := consumers.BuildIndex(words, index)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then each term is mapped to the items containing it", func() {
+					So(index, ShouldResemble, map[string][]stream.T{
+						"hello":  []stream.T{"hello world", "hello rivers"},
+						"world":  []stream.T{"hello world"},
+						"rivers": []stream.T{"hello rivers"},
+					})
+				})
+			})
+		})
+	})
+}
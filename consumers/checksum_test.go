@@ -0,0 +1,56 @@
+package consumers_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	bytesOf := func(data stream.T) []byte { return []byte(fmt.Sprint(data)) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the consumer", func() {
+				h := sha256.New()
+				consumer := consumers.Checksum(h, bytesOf)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the digest covers every item in order", func() {
+					expected := sha256.Sum256([]byte("123"))
+					So(context.Err(), ShouldBeNil)
+					So(h.Sum(nil), ShouldResemble, expected[:])
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the consumer", func() {
+					h := sha256.New()
+					consumer := consumers.Checksum(h, bytesOf)
+					consumer.Attach(context)
+					consumer.Consume(in)
+
+					Convey("Then no item is fed into the hash", func() {
+						empty := sha256.Sum256(nil)
+						So(h.Sum(nil), ShouldResemble, empty[:])
+					})
+				})
+			})
+		})
+	})
+}
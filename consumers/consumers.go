@@ -3,6 +3,7 @@ package consumers
 import (
 	"errors"
 	"github.com/drborges/rivers/stream"
+	"hash"
 	"reflect"
 )
 
@@ -63,3 +64,35 @@ func GroupBy(fn stream.MapFn, result stream.Groups) stream.Consumer {
 		},
 	}
 }
+
+// Checksum feeds the bytes of every consumed item into h, whose digest
+// identifies the stream's content once it is fully consumed
+func Checksum(h hash.Hash, bytesOf func(stream.T) []byte) stream.Consumer {
+	return &Sink{
+		OnNext: func(data stream.T) {
+			h.Write(bytesOf(data))
+		},
+	}
+}
+
+func SendGRPC(send func(stream.T) error) stream.Consumer {
+	return &Sink{
+		OnNext: func(data stream.T) {
+			if err := send(data); err != nil {
+				panic(err)
+			}
+		},
+	}
+}
+
+// BuildIndex keeps every consumed item in memory, referenced once per
+// term it contains, so large corpora should be indexed in chunks
+func BuildIndex(terms func(stream.T) []string, index map[string][]stream.T) stream.Consumer {
+	return &Sink{
+		OnNext: func(data stream.T) {
+			for _, term := range terms(data) {
+				index[term] = append(index[term], data)
+			}
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package consumers
+
+import "github.com/drborges/rivers/stream"
+
+type errorsCollector struct {
+	context   stream.Context
+	fn        func(stream.T) error
+	maxErrors int
+	processed int
+	errs      []error
+}
+
+// ForEachCollectingErrors runs fn on every item without stopping on its
+// errors, which are collected for a report once the stream is consumed.
+// All errors are kept by default, see MaxErrors for bounding them.
+func ForEachCollectingErrors(fn func(stream.T) error) *errorsCollector {
+	return &errorsCollector{
+		fn: fn,
+	}
+}
+
+// MaxErrors keeps only the first n errors, items are still processed
+func (collector *errorsCollector) MaxErrors(n int) *errorsCollector {
+	collector.maxErrors = n
+	return collector
+}
+
+// Processed is the number of items fn ran on, failed ones included
+func (collector *errorsCollector) Processed() int {
+	return collector.processed
+}
+
+func (collector *errorsCollector) Errors() []error {
+	return collector.errs
+}
+
+func (collector *errorsCollector) Attach(context stream.Context) {
+	collector.context = context
+}
+
+func (collector *errorsCollector) Consume(in stream.Readable) {
+	sink := &Sink{
+		OnNext: func(data stream.T) {
+			collector.processed++
+			err := collector.fn(data)
+			if err == nil {
+				return
+			}
+
+			if collector.maxErrors <= 0 || len(collector.errs) < collector.maxErrors {
+				collector.errs = append(collector.errs, err)
+			}
+		},
+	}
+
+	sink.Attach(collector.context)
+	sink.Consume(in)
+}
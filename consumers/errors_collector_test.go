@@ -0,0 +1,72 @@
+package consumers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestForEachCollectingErrors(t *testing.T) {
+	failure := errors.New("odd item")
+	failOnOdds := func(data stream.T) error {
+		if data.(int)%2 != 0 {
+			return failure
+		}
+		return nil
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the consumer", func() {
+				consumer := consumers.ForEachCollectingErrors(failOnOdds)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then every item is processed and every error collected", func() {
+					So(consumer.Processed(), ShouldEqual, 5)
+					So(consumer.Errors(), ShouldResemble, []error{failure, failure, failure})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I bound the number of collected errors", func() {
+				consumer := consumers.ForEachCollectingErrors(failOnOdds).MaxErrors(1)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are still processed past the bound", func() {
+					So(consumer.Processed(), ShouldEqual, 5)
+					So(consumer.Errors(), ShouldResemble, []error{failure})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the consumer", func() {
+					consumer := consumers.ForEachCollectingErrors(failOnOdds)
+					consumer.Attach(context)
+					consumer.Consume(in)
+
+					Convey("Then no item is processed", func() {
+						So(consumer.Processed(), ShouldEqual, 0)
+						So(consumer.Errors(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
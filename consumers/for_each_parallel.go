@@ -0,0 +1,65 @@
+package consumers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type forEachParallel struct {
+	context stream.Context
+	workers int
+	fn      func(stream.T) error
+}
+
+// ForEachParallel runs fn on every item using a pool of workers. The first
+// error returned by fn closes the context, cancelling upstream, and the
+// consumer only returns once every in-flight call is finished.
+func ForEachParallel(workers int, fn func(stream.T) error) stream.Consumer {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	return &forEachParallel{
+		workers: workers,
+		fn:      fn,
+	}
+}
+
+func (consumer *forEachParallel) Attach(context stream.Context) {
+	consumer.context = context
+}
+
+func (consumer *forEachParallel) Consume(in stream.Readable) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < consumer.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer consumer.context.Recover()
+
+			for {
+				select {
+				case <-consumer.context.Failure():
+					return
+				case <-time.After(consumer.context.Deadline()):
+					panic(stream.Timeout)
+				case data, more := <-in:
+					if !more {
+						return
+					}
+
+					if err := consumer.fn(data); err != nil {
+						// Only the first error is reported, Close ignores
+						// the ones of workers failing concurrently
+						consumer.context.Close(err)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,74 @@
+package consumers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForEachParallel(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			close(out)
+
+			Convey("When I apply the consumer with a slow function", func() {
+				var mutex sync.Mutex
+				var processed []stream.T
+				consumer := consumers.ForEachParallel(4, func(data stream.T) error {
+					time.Sleep(100 * time.Millisecond)
+					mutex.Lock()
+					defer mutex.Unlock()
+					processed = append(processed, data)
+					return nil
+				})
+				consumer.Attach(context)
+
+				start := time.Now()
+				consumer.Consume(in)
+
+				Convey("Then items are processed concurrently and all workers are awaited", func() {
+					So(time.Since(start), ShouldBeLessThan, 300*time.Millisecond)
+					So(len(processed), ShouldEqual, 4)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When the function fails", func() {
+				failure := errors.New("post failed")
+				consumer := consumers.ForEachParallel(2, func(data stream.T) error {
+					return failure
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the first error", func() {
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+
+			Convey("When the function panics", func() {
+				consumer := consumers.ForEachParallel(2, func(data stream.T) error {
+					panic("post crashed")
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the panic before the consumer returns", func() {
+					So(context.Err().Error(), ShouldEqual, "Recovered from post crashed")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package consumers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSendGRPC(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the consumer", func() {
+				var sent []stream.T
+				consumer := consumers.SendGRPC(func(data stream.T) error {
+					sent = append(sent, data)
+					return nil
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then all items are sent", func() {
+					So(sent, ShouldResemble, []stream.T{1, 2, 3})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When sending an item fails", func() {
+				var sent []stream.T
+				failure := errors.New("send failed")
+				consumer := consumers.SendGRPC(func(data stream.T) error {
+					if data == 2 {
+						return failure
+					}
+					sent = append(sent, data)
+					return nil
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the send error", func() {
+					So(sent, ShouldResemble, []stream.T{1})
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package consumers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"sync/atomic"
+)
+
+type shadowConsumer struct {
+	context   stream.Context
+	primary   func(stream.T) error
+	shadow    func(stream.T) error
+	queueSize int
+	dropped   int64
+	failed    int64
+}
+
+// Shadow sends every item to primary, whose errors close the context, and
+// replicates it to shadow, a code path under test whose errors and panics are
+// only counted. Shadow runs asynchronously off a bounded queue of 100 items so
+// it cannot slow primary down, items not fitting in the queue are dropped, see
+// QueueSize. Queued items are skipped once the context fails.
+func Shadow(primary, shadow func(stream.T) error) *shadowConsumer {
+	return &shadowConsumer{
+		primary:   primary,
+		shadow:    shadow,
+		queueSize: 100,
+	}
+}
+
+// QueueSize bounds the number of items waiting for the shadow handler
+func (consumer *shadowConsumer) QueueSize(n int) *shadowConsumer {
+	consumer.queueSize = n
+	return consumer
+}
+
+// Dropped is the number of items the shadow handler missed due to pressure
+func (consumer *shadowConsumer) Dropped() int {
+	return int(atomic.LoadInt64(&consumer.dropped))
+}
+
+// Failed is the number of items the shadow handler returned an error or
+// panicked for
+func (consumer *shadowConsumer) Failed() int {
+	return int(atomic.LoadInt64(&consumer.failed))
+}
+
+func (consumer *shadowConsumer) Attach(context stream.Context) {
+	consumer.context = context
+}
+
+func (consumer *shadowConsumer) Consume(in stream.Readable) {
+	queue := make(chan stream.T, consumer.queueSize)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for data := range queue {
+			select {
+			case <-consumer.context.Failure():
+				continue
+			default:
+			}
+
+			consumer.replicate(data)
+		}
+	}()
+
+	sink := &Sink{
+		OnNext: func(data stream.T) {
+			if err := consumer.primary(data); err != nil {
+				panic(err)
+			}
+
+			select {
+			case queue <- data:
+			default:
+				atomic.AddInt64(&consumer.dropped, 1)
+			}
+		},
+	}
+
+	sink.Attach(consumer.context)
+	sink.Consume(in)
+
+	close(queue)
+	wg.Wait()
+}
+
+// replicate calls the shadow handler with data, counting both its errors and
+// panics as failures so the code path under test cannot crash the process
+func (consumer *shadowConsumer) replicate(data stream.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			atomic.AddInt64(&consumer.failed, 1)
+		}
+	}()
+
+	if err := consumer.shadow(data); err != nil {
+		atomic.AddInt64(&consumer.failed, 1)
+	}
+}
@@ -0,0 +1,108 @@
+package consumers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShadow(t *testing.T) {
+	failure := errors.New("odd item")
+	failOnOdds := func(data stream.T) error {
+		if data.(int)%2 != 0 {
+			return failure
+		}
+		return nil
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I shadow a primary handler with a failing one", func() {
+				var primaries []stream.T
+				primary := func(data stream.T) error {
+					primaries = append(primaries, data)
+					return nil
+				}
+
+				consumer := consumers.Shadow(primary, failOnOdds)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then shadow errors are counted without affecting the primary", func() {
+					So(primaries, ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+					So(consumer.Failed(), ShouldEqual, 3)
+					So(consumer.Dropped(), ShouldEqual, 0)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When the primary handler fails", func() {
+				consumer := consumers.Shadow(failOnOdds, func(stream.T) error { return nil })
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with its error", func() {
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+
+			Convey("When the shadow handler panics", func() {
+				var primaries []stream.T
+				primary := func(data stream.T) error {
+					primaries = append(primaries, data)
+					return nil
+				}
+
+				consumer := consumers.Shadow(primary, func(stream.T) error { panic("shadow crashed") })
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then panics are counted as failures and the primary still drains", func() {
+					So(primaries, ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+					So(consumer.Failed(), ShouldEqual, 5)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When the shadow handler cannot keep up", func() {
+				release := make(chan struct{})
+				primary := func(data stream.T) error {
+					if data == 5 {
+						close(release)
+					}
+					return nil
+				}
+
+				var shadowed int64
+				shadow := func(stream.T) error {
+					<-release
+					atomic.AddInt64(&shadowed, 1)
+					return nil
+				}
+
+				consumer := consumers.Shadow(primary, shadow).QueueSize(1)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items not fitting in the queue are dropped", func() {
+					So(consumer.Dropped(), ShouldBeGreaterThanOrEqualTo, 2)
+					So(int(shadowed)+consumer.Dropped(), ShouldEqual, 5)
+				})
+			})
+		})
+	})
+}
@@ -18,6 +18,13 @@ func (sink *Sink) Consume(in stream.Readable) {
 	defer sink.context.Recover()
 
 	for {
+		// a failure takes priority over items ready to be read
+		select {
+		case <-sink.context.Failure():
+			return
+		default:
+		}
+
 		select {
 		case <-sink.context.Failure():
 			return
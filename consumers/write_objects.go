@@ -0,0 +1,88 @@
+package consumers
+
+import "github.com/drborges/rivers/stream"
+
+// ObjectStore is implemented by users to plug their object storage
+// client in, e.g. S3 or GCS
+type ObjectStore interface {
+	Put(key string, data []byte) error
+}
+
+type objectWriter struct {
+	context  stream.Context
+	store    ObjectStore
+	keyFor   func(stream.T) string
+	encode   func(stream.T) ([]byte, error)
+	maxBytes int
+}
+
+// WriteObjects puts every item encoded as its own object under the key
+// given by keyFor. The first failing encoding or put closes the context.
+func WriteObjects(store ObjectStore, keyFor func(stream.T) string, encode func(stream.T) ([]byte, error)) *objectWriter {
+	return &objectWriter{
+		store:  store,
+		keyFor: keyFor,
+		encode: encode,
+	}
+}
+
+// BatchBytes concatenates encoded items into objects of up to maxBytes,
+// each stored under the key of its first item. A single item larger
+// than maxBytes still makes up an object of its own.
+func (writer *objectWriter) BatchBytes(maxBytes int) *objectWriter {
+	writer.maxBytes = maxBytes
+	return writer
+}
+
+func (writer *objectWriter) Attach(context stream.Context) {
+	writer.context = context
+}
+
+func (writer *objectWriter) Consume(in stream.Readable) {
+	defer writer.context.Recover()
+
+	var key string
+	var batch []byte
+	put := func() {
+		if err := writer.store.Put(key, batch); err != nil {
+			panic(err)
+		}
+		batch = nil
+	}
+
+	sink := &Sink{
+		OnNext: func(data stream.T) {
+			encoded, err := writer.encode(data)
+			if err != nil {
+				panic(err)
+			}
+
+			if batch != nil && len(batch)+len(encoded) > writer.maxBytes {
+				put()
+			}
+
+			if batch == nil {
+				key = writer.keyFor(data)
+				batch = []byte{}
+			}
+			batch = append(batch, encoded...)
+
+			if len(batch) >= writer.maxBytes {
+				put()
+			}
+		},
+	}
+
+	sink.Attach(writer.context)
+	sink.Consume(in)
+
+	select {
+	case <-writer.context.Failure():
+		return
+	default:
+	}
+
+	if batch != nil {
+		put()
+	}
+}
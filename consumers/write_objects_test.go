@@ -0,0 +1,77 @@
+package consumers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type bucket map[string]string
+
+func (b bucket) Put(key string, data []byte) error {
+	b[key] = string(data)
+	return nil
+}
+
+type failingBucket struct {
+	err error
+}
+
+func (b failingBucket) Put(key string, data []byte) error {
+	return b.err
+}
+
+func TestWriteObjects(t *testing.T) {
+	keyFor := func(data stream.T) string { return "key-" + data.(string) }
+	encode := func(data stream.T) ([]byte, error) { return []byte(data.(string)), nil }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- "a"
+			out <- "bb"
+			out <- "c"
+			close(out)
+
+			Convey("When I apply the consumer", func() {
+				store := bucket{}
+				consumer := consumers.WriteObjects(store, keyFor, encode)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then every item is put as its own object", func() {
+					So(context.Err(), ShouldBeNil)
+					So(store, ShouldResemble, bucket{"key-a": "a", "key-bb": "bb", "key-c": "c"})
+				})
+			})
+
+			Convey("When I batch items into objects of up to 3 bytes", func() {
+				store := bucket{}
+				consumer := consumers.WriteObjects(store, keyFor, encode).BatchBytes(3)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then items are put together under the key of the first one", func() {
+					So(context.Err(), ShouldBeNil)
+					So(store, ShouldResemble, bucket{"key-a": "abb", "key-c": "c"})
+				})
+			})
+
+			Convey("When putting an object fails", func() {
+				failure := errors.New("put failed")
+				consumer := consumers.WriteObjects(failingBucket{failure}, keyFor, encode)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the error", func() {
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package consumers
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/drborges/rivers/stream"
+)
+
+var ErrInvalidBatchSize = errors.New("Batch size must be greater than zero")
+
+type sqlWriter struct {
+	context   stream.Context
+	db        *sql.DB
+	batchSize int
+	exec      func(tx *sql.Tx, items []stream.T) error
+}
+
+// WriteSQL loads items into db in batches of batchSize, each one executed
+// by exec within its own transaction. A failing batch is rolled back and
+// the context closed with its error; the final partial batch is committed
+// once the stream is over. A batch size not greater than zero closes the
+// context with ErrInvalidBatchSize before reading any item.
+func WriteSQL(db *sql.DB, batchSize int, exec func(tx *sql.Tx, items []stream.T) error) stream.Consumer {
+	return &sqlWriter{
+		db:        db,
+		batchSize: batchSize,
+		exec:      exec,
+	}
+}
+
+func (writer *sqlWriter) Attach(context stream.Context) {
+	writer.context = context
+}
+
+func (writer *sqlWriter) Consume(in stream.Readable) {
+	defer writer.context.Recover()
+
+	if writer.batchSize <= 0 {
+		panic(ErrInvalidBatchSize)
+	}
+
+	batch := []stream.T{}
+	commit := func() {
+		tx, err := writer.db.Begin()
+		if err != nil {
+			panic(err)
+		}
+
+		if err := writer.exec(tx, batch); err != nil {
+			tx.Rollback()
+			panic(err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			panic(err)
+		}
+		batch = []stream.T{}
+	}
+
+	sink := &Sink{
+		OnNext: func(data stream.T) {
+			batch = append(batch, data)
+			if len(batch) >= writer.batchSize {
+				commit()
+			}
+		},
+	}
+
+	sink.Attach(writer.context)
+	sink.Consume(in)
+
+	select {
+	case <-writer.context.Failure():
+		return
+	default:
+	}
+
+	if len(batch) > 0 {
+		commit()
+	}
+}
@@ -0,0 +1,98 @@
+package consumers_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type fakeSQL struct {
+	commits   int
+	rollbacks int
+}
+
+func (fake *fakeSQL) Open(name string) (driver.Conn, error) { return fake, nil }
+func (fake *fakeSQL) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (fake *fakeSQL) Close() error              { return nil }
+func (fake *fakeSQL) Begin() (driver.Tx, error) { return fake, nil }
+func (fake *fakeSQL) Commit() error             { fake.commits++; return nil }
+func (fake *fakeSQL) Rollback() error           { fake.rollbacks++; return nil }
+
+var fakeDriver = &fakeSQL{}
+
+func init() {
+	sql.Register("fakesql", fakeDriver)
+}
+
+func TestWriteSQL(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		db, _ := sql.Open("fakesql", "")
+		*fakeDriver = fakeSQL{}
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the consumer", func() {
+				batches := [][]stream.T{}
+				consumer := consumers.WriteSQL(db, 2, func(tx *sql.Tx, items []stream.T) error {
+					batches = append(batches, items)
+					return nil
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then each batch is committed in its own transaction", func() {
+					So(context.Err(), ShouldBeNil)
+					So(batches, ShouldResemble, [][]stream.T{{1, 2}, {3, 4}, {5}})
+					So(fakeDriver.commits, ShouldEqual, 3)
+					So(fakeDriver.rollbacks, ShouldEqual, 0)
+				})
+			})
+
+			Convey("When I apply the consumer with an invalid batch size", func() {
+				executed := false
+				consumer := consumers.WriteSQL(db, 0, func(tx *sql.Tx, items []stream.T) error {
+					executed = true
+					return nil
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with an error before any batch runs", func() {
+					So(context.Err(), ShouldEqual, consumers.ErrInvalidBatchSize)
+					So(executed, ShouldBeFalse)
+					So(fakeDriver.commits, ShouldEqual, 0)
+				})
+			})
+
+			Convey("When executing a batch fails", func() {
+				failure := errors.New("exec failed")
+				consumer := consumers.WriteSQL(db, 2, func(tx *sql.Tx, items []stream.T) error {
+					return failure
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the batch is rolled back and the context closed with the error", func() {
+					So(context.Err(), ShouldEqual, failure)
+					So(fakeDriver.commits, ShouldEqual, 0)
+					So(fakeDriver.rollbacks, ShouldEqual, 1)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package consumers
+
+import (
+	"bytes"
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"net/http"
+	"time"
+)
+
+var ErrNoSuchFlusher = errors.New("Response writer does not support flushing")
+
+type sseWriter struct {
+	context stream.Context
+	w       http.ResponseWriter
+	r       *http.Request
+	encode  func(stream.T) (event string, data []byte, err error)
+}
+
+// WriteSSE writes every item to w as a server-sent event, flushed right
+// away. The connection is kept open until the stream is over; a client
+// disconnecting from r closes the context with the request's error.
+func WriteSSE(w http.ResponseWriter, r *http.Request, encode func(stream.T) (event string, data []byte, err error)) stream.Consumer {
+	return &sseWriter{
+		w:      w,
+		r:      r,
+		encode: encode,
+	}
+}
+
+func (writer *sseWriter) Attach(context stream.Context) {
+	writer.context = context
+}
+
+func (writer *sseWriter) Consume(in stream.Readable) {
+	defer writer.context.Recover()
+
+	flusher, ok := writer.w.(http.Flusher)
+	if !ok {
+		panic(ErrNoSuchFlusher)
+	}
+
+	header := writer.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	writer.w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-writer.context.Failure():
+			return
+		case <-writer.r.Context().Done():
+			writer.context.Close(writer.r.Context().Err())
+			return
+		case <-time.After(writer.context.Deadline()):
+			panic(stream.Timeout)
+		case data, more := <-in:
+			if !more {
+				return
+			}
+
+			event, payload, err := writer.encode(data)
+			if err != nil {
+				panic(err)
+			}
+
+			if _, err := writer.w.Write(sseFrame(event, payload)); err != nil {
+				panic(err)
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// sseFrame formats an event, splitting multi-line payloads into one
+// data field per line as required by the SSE format
+func sseFrame(event string, payload []byte) []byte {
+	var frame bytes.Buffer
+	if event != "" {
+		frame.WriteString("event: " + event + "\n")
+	}
+
+	for _, line := range bytes.Split(payload, []byte("\n")) {
+		frame.WriteString("data: ")
+		frame.Write(line)
+		frame.WriteString("\n")
+	}
+
+	frame.WriteString("\n")
+	return frame.Bytes()
+}
@@ -0,0 +1,54 @@
+package consumers_test
+
+import (
+	gocontext "context"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteSSE(t *testing.T) {
+	encode := func(data stream.T) (string, []byte, error) { return "tick", []byte(data.(string)), nil }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- "hi"
+			out <- "multi\nline"
+
+			Convey("When I apply the consumer", func() {
+				close(out)
+				recorder := httptest.NewRecorder()
+				consumer := consumers.WriteSSE(recorder, httptest.NewRequest("GET", "/events", nil), encode)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then every item is written as a flushed server-sent event", func() {
+					So(context.Err(), ShouldBeNil)
+					So(recorder.Header().Get("Content-Type"), ShouldEqual, "text/event-stream")
+					So(recorder.Flushed, ShouldBeTrue)
+					So(recorder.Body.String(), ShouldEqual, "event: tick\ndata: hi\n\nevent: tick\ndata: multi\ndata: line\n\n")
+				})
+			})
+
+			Convey("When the client disconnects", func() {
+				request := httptest.NewRequest("GET", "/events", nil)
+				ctx, cancel := gocontext.WithCancel(request.Context())
+				cancel()
+
+				consumer := consumers.WriteSSE(httptest.NewRecorder(), request.WithContext(ctx), encode)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the request's error", func() {
+					So(context.Err(), ShouldEqual, gocontext.Canceled)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package consumers
+
+import (
+	"encoding/binary"
+	"github.com/drborges/rivers/stream"
+	"os"
+	"time"
+)
+
+type walWriter struct {
+	context      stream.Context
+	path         string
+	encode       func(stream.T) ([]byte, error)
+	syncEvery    int
+	syncInterval time.Duration
+}
+
+// WriteWAL appends length-prefixed records to the log at path in the
+// format replayed by producers.FromWAL. By default every record is
+// fsynced, see SyncEvery and SyncInterval for batching syncs.
+func WriteWAL(path string, encode func(stream.T) ([]byte, error)) *walWriter {
+	return &walWriter{
+		path:      path,
+		encode:    encode,
+		syncEvery: 1,
+	}
+}
+
+func (writer *walWriter) SyncEvery(records int) *walWriter {
+	writer.syncEvery = records
+	return writer
+}
+
+func (writer *walWriter) SyncInterval(interval time.Duration) *walWriter {
+	writer.syncInterval = interval
+	return writer
+}
+
+func (writer *walWriter) Attach(context stream.Context) {
+	writer.context = context
+}
+
+func (writer *walWriter) Consume(in stream.Readable) {
+	defer writer.context.Recover()
+
+	file, err := os.OpenFile(writer.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	unsynced := 0
+	lastSync := time.Now()
+	sync := func() {
+		if err := file.Sync(); err != nil {
+			panic(err)
+		}
+		unsynced = 0
+		lastSync = time.Now()
+	}
+
+	sink := &Sink{
+		OnNext: func(data stream.T) {
+			record, err := writer.encode(data)
+			if err != nil {
+				panic(err)
+			}
+
+			frame := make([]byte, 4+len(record))
+			binary.BigEndian.PutUint32(frame, uint32(len(record)))
+			copy(frame[4:], record)
+			if _, err := file.Write(frame); err != nil {
+				panic(err)
+			}
+
+			unsynced++
+			if writer.syncEvery > 0 && unsynced >= writer.syncEvery ||
+				writer.syncInterval > 0 && time.Since(lastSync) >= writer.syncInterval {
+				sync()
+			}
+		},
+	}
+
+	sink.Attach(writer.context)
+	sink.Consume(in)
+
+	if unsynced > 0 {
+		sync()
+	}
+}
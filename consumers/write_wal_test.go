@@ -0,0 +1,56 @@
+package consumers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/consumers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"os"
+	"testing"
+)
+
+func TestWriteWAL(t *testing.T) {
+	encode := func(data stream.T) ([]byte, error) { return []byte(data.(string)), nil }
+	decode := func(record []byte) (stream.T, error) { return string(record), nil }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		os.Remove("/tmp/write_wal")
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- "hi"
+			out <- "there"
+			close(out)
+
+			Convey("When I apply the write-ahead log consumer", func() {
+				consumer := consumers.WriteWAL("/tmp/write_wal", encode).SyncEvery(10)
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the records can be replayed from the log", func() {
+					So(context.Err(), ShouldBeNil)
+
+					items, err := rivers.From(producers.FromWAL("/tmp/write_wal", decode)).Collect()
+					So(err, ShouldBeNil)
+					So(items, ShouldResemble, []stream.T{"hi", "there"})
+				})
+			})
+
+			Convey("When encoding an item fails", func() {
+				failure := errors.New("encoding failed")
+				consumer := consumers.WriteWAL("/tmp/write_wal", func(data stream.T) ([]byte, error) {
+					return nil, failure
+				})
+				consumer.Attach(context)
+				consumer.Consume(in)
+
+				Convey("Then the context is closed with the encoding error", func() {
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+		})
+	})
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"github.com/drborges/rivers/stream"
 	"runtime/debug"
+	"sync"
 	"time"
 )
 
 var DebugEnabled = false
 
 type context struct {
-	requests chan error
+	mutex    sync.Mutex
 	success  chan struct{}
 	failure  chan struct{}
 	deadline time.Duration
@@ -20,7 +21,6 @@ type context struct {
 
 func NewContext() stream.Context {
 	return &context{
-		requests: make(chan error, 1),
 		success:  make(chan struct{}),
 		failure:  make(chan struct{}),
 		deadline: time.Hour,
@@ -28,14 +28,20 @@ func NewContext() stream.Context {
 }
 
 func (context *context) Err() error {
+	context.mutex.Lock()
+	defer context.mutex.Unlock()
 	return context.err
 }
 
 func (context *context) Deadline() time.Duration {
+	context.mutex.Lock()
+	defer context.mutex.Unlock()
 	return context.deadline
 }
 
 func (context *context) SetDeadline(duration time.Duration) {
+	context.mutex.Lock()
+	defer context.mutex.Unlock()
 	context.deadline = duration
 }
 
@@ -47,8 +53,14 @@ func (context *context) Done() <-chan struct{} {
 	return context.success
 }
 
+// Close is safe to call concurrently and more than once: closing with a
+// nil error signals done, any other error signals failure, and only the
+// first close of each kind has an effect. A failure following a graceful
+// stop is still reported, but a graceful stop never clears a failure.
 func (context *context) Close(err error) {
-	context.requests <- err
+	context.mutex.Lock()
+	defer context.mutex.Unlock()
+
 	ch := context.success
 	if err != nil {
 		ch = context.failure
@@ -58,14 +70,22 @@ func (context *context) Close(err error) {
 	case <-ch:
 		return
 	default:
+		if err != nil || context.err == nil {
+			context.err = err
+		}
 		close(ch)
-		context.err = <-context.requests
 	}
 }
 
 func (context *context) Recover() {
 	if r := recover(); r != nil {
-		if DebugEnabled && r != stream.Done {
+		// Emitters panic with Done once the context is already closed,
+		// closing it again would turn a graceful stop into a failure
+		if r == stream.Done {
+			return
+		}
+
+		if DebugEnabled {
 			debug.PrintStack()
 		}
 		err := errors.New(fmt.Sprintf("Recovered from %v", r))
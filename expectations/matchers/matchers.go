@@ -0,0 +1,88 @@
+package matchers
+
+import (
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"reflect"
+	"time"
+)
+
+// Timeout bounds how long ShouldMatchStream waits on each stream when no
+// timeout is given
+var Timeout = time.Second
+
+// ShouldMatchStream is a goconvey assertion draining the actual and expected
+// readable streams and checking they emit equal items in the same order:
+//
+//	So(actual, ShouldMatchStream, expected)
+//	So(actual, ShouldMatchStream, expected, 5*time.Second)
+//
+// It reports the first index at which the streams differ, length mismatches
+// and streams not closed within the timeout.
+func ShouldMatchStream(actual interface{}, expected ...interface{}) string {
+	if len(expected) < 1 || len(expected) > 2 {
+		return "This assertion requires the expected stream and optionally a timeout"
+	}
+
+	actualStream, ok := actual.(stream.Readable)
+	if !ok {
+		return fmt.Sprintf("Expected actual to be a stream.Readable but got %T", actual)
+	}
+
+	expectedStream, ok := expected[0].(stream.Readable)
+	if !ok {
+		return fmt.Sprintf("Expected the expected value to be a stream.Readable but got %T", expected[0])
+	}
+
+	timeout := Timeout
+	if len(expected) == 2 {
+		if timeout, ok = expected[1].(time.Duration); !ok {
+			return fmt.Sprintf("Expected the timeout to be a time.Duration but got %T", expected[1])
+		}
+	}
+
+	actualItems, closed := readAll(actualStream, timeout)
+	if !closed {
+		return fmt.Sprintf("Timed out after %v waiting for the actual stream to close, read %d items", timeout, len(actualItems))
+	}
+
+	expectedItems, closed := readAll(expectedStream, timeout)
+	if !closed {
+		return fmt.Sprintf("Timed out after %v waiting for the expected stream to close, read %d items", timeout, len(expectedItems))
+	}
+
+	shortest := len(actualItems)
+	if len(expectedItems) < shortest {
+		shortest = len(expectedItems)
+	}
+
+	for i := 0; i < shortest; i++ {
+		if !reflect.DeepEqual(actualItems[i], expectedItems[i]) {
+			return fmt.Sprintf("Expected streams to match but they differ at index %d: expected %#v, got %#v", i, expectedItems[i], actualItems[i])
+		}
+	}
+
+	if len(actualItems) != len(expectedItems) {
+		return fmt.Sprintf("Expected a stream of %d items but got %d, the first %d match", len(expectedItems), len(actualItems), shortest)
+	}
+
+	return ""
+}
+
+// readAll drains readable giving up once timeout elapses, in which case
+// false is returned along with the items read so far
+func readAll(readable stream.Readable, timeout time.Duration) ([]stream.T, bool) {
+	items := []stream.T{}
+	deadline := time.After(timeout)
+	for {
+		select {
+		case <-deadline:
+			return items, false
+		case data, more := <-readable:
+			if !more {
+				return items, true
+			}
+			items = append(items, data)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package matchers_test
+
+import (
+	"github.com/drborges/rivers/expectations/matchers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestShouldMatchStream(t *testing.T) {
+	streamOf := func(items ...stream.T) stream.Readable {
+		readable, writable := stream.New(len(items))
+		for _, item := range items {
+			writable <- item
+		}
+		close(writable)
+		return readable
+	}
+
+	Convey("Given I have a couple of streams", t, func() {
+		Convey("When they emit equal items in the same order", func() {
+			result := matchers.ShouldMatchStream(streamOf(1, 2, 3), streamOf(1, 2, 3))
+
+			Convey("Then they match", func() {
+				So(result, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When they differ at some index", func() {
+			result := matchers.ShouldMatchStream(streamOf(1, 5, 3), streamOf(1, 2, 3))
+
+			Convey("Then the first differing index is reported", func() {
+				So(result, ShouldContainSubstring, "differ at index 1")
+			})
+		})
+
+		Convey("When the actual stream is shorter", func() {
+			result := matchers.ShouldMatchStream(streamOf(1, 2), streamOf(1, 2, 3))
+
+			Convey("Then the length mismatch is reported", func() {
+				So(result, ShouldEqual, "Expected a stream of 3 items but got 2, the first 2 match")
+			})
+		})
+
+		Convey("When the actual stream is never closed", func() {
+			readable, writable := stream.New(1)
+			writable <- 1
+			result := matchers.ShouldMatchStream(readable, streamOf(1), 10*time.Millisecond)
+
+			Convey("Then the timeout is reported", func() {
+				So(result, ShouldContainSubstring, "Timed out after 10ms waiting for the actual stream")
+			})
+		})
+
+		Convey("When I use it as a goconvey assertion", func() {
+			Convey("Then it passes for matching streams", func() {
+				So(streamOf("a", "b"), matchers.ShouldMatchStream, streamOf("a", "b"))
+			})
+		})
+	})
+}
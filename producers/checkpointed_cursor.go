@@ -0,0 +1,63 @@
+package producers
+
+import "github.com/drborges/rivers/stream"
+
+// CheckpointStore persists the cursor of a CheckpointedCursor across runs,
+// Load returns a nil cursor when no checkpoint was saved yet
+type CheckpointStore interface {
+	Load() (cursor []byte, err error)
+	Save(cursor []byte) error
+}
+
+type FetchFn func(cursor []byte) (items []stream.T, next []byte, done bool, err error)
+
+type checkpointedCursor struct {
+	*Observable
+	store CheckpointStore
+	fetch FetchFn
+}
+
+// CheckpointedCursor emits the items of every page returned by fetch,
+// starting from the cursor saved in store. The next cursor is saved once
+// a page is fully handed off, so a cancelled or failed export resumes
+// from the page it was emitting and its items may be emitted twice.
+func CheckpointedCursor(store CheckpointStore, fetch FetchFn) stream.Producer {
+	producer := &checkpointedCursor{store: store, fetch: fetch}
+	producer.Observable = &Observable{Emit: producer.emit}
+	return producer
+}
+
+func (producer *checkpointedCursor) emit(emitter stream.Emitter) {
+	cursor, err := producer.store.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	for {
+		select {
+		case <-producer.context.Failure():
+			return
+		case <-producer.context.Done():
+			return
+		default:
+		}
+
+		items, next, done, err := producer.fetch(cursor)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, item := range items {
+			emitter.Emit(item)
+		}
+
+		if err := producer.store.Save(next); err != nil {
+			panic(err)
+		}
+
+		if done {
+			return
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,88 @@
+package producers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type memoryStore struct {
+	cursor []byte
+}
+
+func (store *memoryStore) Load() ([]byte, error) {
+	return store.cursor, nil
+}
+
+func (store *memoryStore) Save(cursor []byte) error {
+	store.cursor = cursor
+	return nil
+}
+
+func TestCheckpointedCursor(t *testing.T) {
+	pages := map[string][]stream.T{
+		"":      {1, 2},
+		"page2": {3},
+	}
+
+	fetch := func(cursor []byte) ([]stream.T, []byte, bool, error) {
+		if string(cursor) == "" {
+			return pages[""], []byte("page2"), false, nil
+		}
+		return pages[string(cursor)], []byte("end"), true, nil
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And an empty checkpoint store", func() {
+			store := &memoryStore{}
+
+			Convey("When I produce a stream from the cursor", func() {
+				producer := producers.CheckpointedCursor(store, fetch)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the items of every page are emitted and the last cursor saved", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+					So(string(store.cursor), ShouldEqual, "end")
+				})
+			})
+
+			Convey("When fetching a page fails", func() {
+				failure := errors.New("fetch failed")
+				producer := producers.CheckpointedCursor(store, func(cursor []byte) ([]stream.T, []byte, bool, error) {
+					if string(cursor) == "page2" {
+						return nil, nil, false, failure
+					}
+					return fetch(cursor)
+				})
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the error and the last handed off page is checkpointed", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2})
+					So(context.Err(), ShouldEqual, failure)
+					So(string(store.cursor), ShouldEqual, "page2")
+				})
+			})
+		})
+
+		Convey("And a checkpoint saved by a previous run", func() {
+			store := &memoryStore{cursor: []byte("page2")}
+
+			Convey("When I produce a stream from the cursor", func() {
+				producer := producers.CheckpointedCursor(store, fetch)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then it resumes from the checkpoint", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{3})
+				})
+			})
+		})
+	})
+}
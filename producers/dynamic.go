@@ -0,0 +1,129 @@
+package producers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+)
+
+type dynamic struct {
+	context   stream.Context
+	readable  stream.Readable
+	writable  stream.Writable
+	mutex     sync.Mutex
+	wg        sync.WaitGroup
+	producing bool
+	finalized bool
+	closing   bool
+	pending   []<-chan stream.T
+	done      chan struct{}
+}
+
+func Dynamic() *dynamic {
+	readable, writable := stream.New(10)
+	return &dynamic{
+		readable: readable,
+		writable: writable,
+		done:     make(chan struct{}),
+	}
+}
+
+func (producer *dynamic) Attach(context stream.Context) {
+	producer.context = context
+}
+
+func (producer *dynamic) Produce() stream.Readable {
+	producer.mutex.Lock()
+	defer producer.mutex.Unlock()
+
+	producer.producing = true
+	for _, ch := range producer.pending {
+		go producer.forward(ch)
+	}
+	producer.pending = nil
+
+	go func() {
+		defer close(producer.writable)
+
+		select {
+		case <-producer.done:
+		case <-producer.context.Failure():
+		case <-producer.context.Done():
+		}
+
+		// no channel is added past this point, so Wait never races with Add
+		producer.mutex.Lock()
+		producer.closing = true
+		producer.mutex.Unlock()
+		producer.wg.Wait()
+	}()
+
+	return producer.readable
+}
+
+// Add merges the given channel into the produced stream until it is closed.
+// Channels added once the context is closed are ignored.
+func (producer *dynamic) Add(ch <-chan stream.T) {
+	producer.mutex.Lock()
+	defer producer.mutex.Unlock()
+
+	if producer.finalized {
+		panic("Producer is already finalized")
+	}
+
+	if producer.closing || producer.stopped() {
+		return
+	}
+
+	producer.wg.Add(1)
+	if !producer.producing {
+		producer.pending = append(producer.pending, ch)
+		return
+	}
+	go producer.forward(ch)
+}
+
+// Finalize lets the produced stream close once all added channels are closed
+func (producer *dynamic) Finalize() {
+	producer.mutex.Lock()
+	defer producer.mutex.Unlock()
+
+	if !producer.finalized {
+		producer.finalized = true
+		close(producer.done)
+	}
+}
+
+func (producer *dynamic) stopped() bool {
+	if producer.context == nil {
+		return false
+	}
+
+	select {
+	case <-producer.context.Failure():
+		return true
+	case <-producer.context.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+func (producer *dynamic) forward(ch <-chan stream.T) {
+	defer producer.context.Recover()
+	defer producer.wg.Done()
+
+	emitter := stream.NewEmitter(producer.context, producer.writable)
+	for {
+		select {
+		case <-producer.context.Failure():
+			return
+		case <-producer.context.Done():
+			return
+		case data, more := <-ch:
+			if !more {
+				return
+			}
+			emitter.Emit(data)
+		}
+	}
+}
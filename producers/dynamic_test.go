@@ -0,0 +1,83 @@
+package producers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestDynamic(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a dynamic producer with a channel added before producing", func() {
+			producer := producers.Dynamic()
+			producer.Attach(context)
+
+			ch1 := make(chan stream.T, 2)
+			ch1 <- 1
+			ch1 <- 2
+			close(ch1)
+			producer.Add(ch1)
+
+			Convey("When I add another channel while producing", func() {
+				readable := producer.Produce()
+
+				ch2 := make(chan stream.T, 1)
+				ch2 <- 3
+				close(ch2)
+				producer.Add(ch2)
+				producer.Finalize()
+
+				Convey("Then I can read the data from all channels", func() {
+					data := readable.ReadAll()
+					So(len(data), ShouldEqual, 3)
+					So(data, ShouldContain, 1)
+					So(data, ShouldContain, 2)
+					So(data, ShouldContain, 3)
+				})
+			})
+
+			Convey("When I finalize the producer", func() {
+				readable := producer.Produce()
+				producer.Finalize()
+
+				Convey("Then no more channels can be added", func() {
+					So(func() { producer.Add(make(chan stream.T)) }, ShouldPanic)
+					So(len(readable.ReadAll()), ShouldEqual, 2)
+				})
+			})
+		})
+
+		Convey("And I have a dynamic producer that is never finalized", func() {
+			producer := producers.Dynamic()
+			producer.Attach(context)
+			readable := producer.Produce()
+
+			Convey("When the context is closed gracefully", func() {
+				context.Close(nil)
+
+				Convey("Then the produced stream is closed", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+
+					Convey("And channels added afterwards are ignored", func() {
+						producer.Add(make(chan stream.T))
+						So(readable.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+
+			Convey("When the context fails", func() {
+				context.Close(errors.New("failure"))
+
+				Convey("Then channels added afterwards are ignored", func() {
+					producer.Add(make(chan stream.T))
+					So(readable.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
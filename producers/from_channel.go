@@ -0,0 +1,32 @@
+package producers
+
+import "github.com/drborges/rivers/stream"
+
+type fromChannel struct {
+	*Observable
+	ch <-chan stream.T
+}
+
+// FromChannel emits every item read from ch until it is closed. It stops
+// reading as soon as the context fails or is done, and never closes ch.
+func FromChannel(ch <-chan stream.T) stream.Producer {
+	producer := &fromChannel{ch: ch}
+	producer.Observable = &Observable{Capacity: cap(ch), Emit: producer.emit}
+	return producer
+}
+
+func (producer *fromChannel) emit(emitter stream.Emitter) {
+	for {
+		select {
+		case <-producer.context.Failure():
+			return
+		case <-producer.context.Done():
+			return
+		case data, more := <-producer.ch:
+			if !more {
+				return
+			}
+			emitter.Emit(data)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package producers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFromChannel(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a producer of a closed channel", func() {
+			ch := make(chan stream.T, 2)
+			ch <- 1
+			ch <- 2
+			close(ch)
+			producer := producers.FromChannel(ch)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then I can read the produced data from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And I have a producer of an open channel", func() {
+			ch := make(chan stream.T)
+			producer := producers.FromChannel(ch)
+			producer.Attach(context)
+
+			Convey("When the context fails before the channel is closed", func() {
+				readable := producer.Produce()
+				failure := errors.New("canceled")
+				context.Close(failure)
+
+				Convey("Then the stream is closed with the context error", func() {
+					readable.ReadAll()
+					So(context.Err(), ShouldEqual, failure)
+
+					Convey("And the channel is neither read nor closed", func() {
+						// sending on ch would panic had it been closed
+						read := false
+						select {
+						case ch <- 1:
+							read = true
+						default:
+						}
+						So(read, ShouldBeFalse)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package producers
+
+import (
+	"bufio"
+	"github.com/drborges/rivers/stream"
+	"io"
+)
+
+// LinesOption configures the scanner FromLines reads with
+type LinesOption func(scanner *bufio.Scanner)
+
+// WithSplitFunc tokenizes the reader with split rather than by line, see
+// bufio.SplitFunc
+func WithSplitFunc(split bufio.SplitFunc) LinesOption {
+	return func(scanner *bufio.Scanner) {
+		scanner.Split(split)
+	}
+}
+
+// WithMaxTokenSize allows tokens up to size bytes, above
+// bufio.MaxScanTokenSize
+func WithMaxTokenSize(size int) LinesOption {
+	return func(scanner *bufio.Scanner) {
+		scanner.Buffer(make([]byte, 0, 4096), size)
+	}
+}
+
+// FromLines emits every line read from r as a string, see LinesOption for
+// customizing how r is scanned. Scanning errors close the context with the
+// error.
+func FromLines(r io.Reader, options ...LinesOption) stream.Producer {
+	return &Observable{
+		Capacity: 100,
+		Emit: func(emitter stream.Emitter) {
+			scanner := bufio.NewScanner(r)
+			for _, option := range options {
+				option(scanner)
+			}
+
+			for scanner.Scan() {
+				emitter.Emit(scanner.Text())
+			}
+
+			if err := scanner.Err(); err != nil {
+				panic(err)
+			}
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package producers_test
+
+import (
+	"bufio"
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+type failingReader struct {
+	err error
+}
+
+func (reader *failingReader) Read(p []byte) (int, error) {
+	return 0, reader.err
+}
+
+func TestFromLines(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a lines producer", func() {
+			producer := producers.FromLines(strings.NewReader("first line\nsecond line\n"))
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then every line is emitted as a string", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"first line", "second line"})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And I have a lines producer splitting by words", func() {
+			producer := producers.FromLines(strings.NewReader("first line\nsecond"), producers.WithSplitFunc(bufio.ScanWords))
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then every word is emitted as a string", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"first", "line", "second"})
+				})
+			})
+		})
+
+		Convey("And I have a lines producer over lines longer than the default token size", func() {
+			line := strings.Repeat("x", bufio.MaxScanTokenSize+1)
+
+			Convey("When I produce data with a larger max token size", func() {
+				producer := producers.FromLines(strings.NewReader(line), producers.WithMaxTokenSize(2*bufio.MaxScanTokenSize))
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the whole line is emitted", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{line})
+				})
+			})
+
+			Convey("When I produce data with the default max token size", func() {
+				producer := producers.FromLines(strings.NewReader(line))
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the scanner error", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, bufio.ErrTooLong)
+				})
+			})
+		})
+
+		Convey("And I have a lines producer over a failing reader", func() {
+			failure := errors.New("read failure")
+			producer := producers.FromLines(&failingReader{failure})
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the reader error", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+		})
+	})
+}
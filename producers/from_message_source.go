@@ -0,0 +1,56 @@
+package producers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"io"
+)
+
+type Message interface{}
+
+// MessageSource is implemented by users to plug their message broker
+// client in. Receive blocks until a message is available, returning io.EOF
+// once there are no more messages; it should give up as soon as the given
+// context fails or is done.
+type MessageSource interface {
+	Receive(context stream.Context) (Message, error)
+	Ack(message Message) error
+}
+
+type fromMessageSource struct {
+	*Observable
+	source MessageSource
+}
+
+// FromMessageSource emits the messages received from src, acking each one
+// only after it is handed off downstream for at-least-once delivery
+func FromMessageSource(src MessageSource) stream.Producer {
+	producer := &fromMessageSource{source: src}
+	producer.Observable = &Observable{Emit: producer.emit}
+	return producer
+}
+
+func (producer *fromMessageSource) emit(emitter stream.Emitter) {
+	for {
+		select {
+		case <-producer.context.Failure():
+			return
+		case <-producer.context.Done():
+			return
+		default:
+		}
+
+		message, err := producer.source.Receive(producer.context)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+
+		emitter.Emit(message)
+
+		if err := producer.source.Ack(message); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package producers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"testing"
+)
+
+type queue struct {
+	messages []producers.Message
+	acked    []producers.Message
+	err      error
+}
+
+func (q *queue) Receive(context stream.Context) (producers.Message, error) {
+	if len(q.messages) == 0 {
+		if q.err != nil {
+			return nil, q.err
+		}
+		return nil, io.EOF
+	}
+
+	message := q.messages[0]
+	q.messages = q.messages[1:]
+	return message, nil
+}
+
+func (q *queue) Ack(message producers.Message) error {
+	q.acked = append(q.acked, message)
+	return nil
+}
+
+func TestFromMessageSource(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a message source", func() {
+			source := &queue{messages: []producers.Message{"a", "b", "c"}}
+
+			Convey("When I produce a stream of messages from it", func() {
+				producer := producers.FromMessageSource(source)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then every message is emitted and acked", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"a", "b", "c"})
+					So(source.acked, ShouldResemble, []producers.Message{"a", "b", "c"})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When receiving a message fails", func() {
+				failure := errors.New("connection lost")
+				source.err = failure
+				producer := producers.FromMessageSource(source)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with the error", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"a", "b", "c"})
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I produce a stream of messages from it", func() {
+					producer := producers.FromMessageSource(source)
+					producer.Attach(context)
+					readable := producer.Produce()
+
+					Convey("Then no message is received", func() {
+						So(readable.ReadAll(), ShouldBeEmpty)
+						So(source.acked, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -24,5 +24,45 @@ func TestFromRange(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("And I have a range producer with a step", func() {
+			producer := producers.FromRangeBy(0, 10, 3)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the end of the range is excluded", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{0, 3, 6, 9})
+				})
+			})
+		})
+
+		Convey("And I have a range producer with a negative step", func() {
+			producer := producers.FromRangeBy(3, 0, -1)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the range counts downward", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{3, 2, 1})
+				})
+			})
+		})
+
+		Convey("And I have a range producer with a zero step", func() {
+			producer := producers.FromRangeBy(0, 10, 0)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with an error", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, producers.ErrInvalidStep)
+				})
+			})
+		})
 	})
 }
@@ -26,6 +26,35 @@ func TestFromSlice(t *testing.T) {
 			})
 		})
 
+		Convey("And I have a producer of an empty slice", func() {
+			producer := producers.FromSlice([]stream.T{})
+
+			Convey("When I produce data", func() {
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then the stream is closed right away", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And I have a slice producer with a closed context", func() {
+			producer := producers.FromSlice([]stream.T{1, 2, 3})
+			context.Close(stream.Done)
+
+			Convey("When I produce data", func() {
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then no item is written to the stream", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, stream.Done)
+				})
+			})
+		})
+
 		Convey("And I have a data producer", func() {
 			producer := producers.FromData(1, 2, 3)
 
@@ -0,0 +1,54 @@
+package producers
+
+import (
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+var ErrInvalidInterval = errors.New("Ticker interval must be greater than zero")
+
+type fromTicker struct {
+	*Observable
+	interval time.Duration
+	count    int
+}
+
+// FromTicker emits an increasing counter, starting at zero, every interval
+// until the context fails or is done.
+func FromTicker(interval time.Duration) stream.Producer {
+	return FromTickerN(interval, -1)
+}
+
+// FromTickerN works like FromTicker, stopping after count ticks. A negative
+// count never stops. An interval not greater than zero closes the context
+// with ErrInvalidInterval.
+func FromTickerN(interval time.Duration, count int) stream.Producer {
+	if interval <= 0 {
+		return &Observable{
+			Emit: func(emitter stream.Emitter) {
+				panic(ErrInvalidInterval)
+			},
+		}
+	}
+
+	producer := &fromTicker{interval: interval, count: count}
+	producer.Observable = &Observable{Emit: producer.emit}
+	return producer
+}
+
+func (producer *fromTicker) emit(emitter stream.Emitter) {
+	ticker := time.NewTicker(producer.interval)
+	defer ticker.Stop()
+
+	for i := 0; producer.count < 0 || i < producer.count; i++ {
+		select {
+		case <-producer.context.Failure():
+			return
+		case <-producer.context.Done():
+			return
+		case <-ticker.C:
+			emitter.Emit(i)
+		}
+	}
+}
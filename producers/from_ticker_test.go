@@ -0,0 +1,61 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestFromTicker(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a ticker producer limited to a few ticks", func() {
+			producer := producers.FromTickerN(time.Millisecond, 3)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then a counter is emitted on every tick", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{0, 1, 2})
+				})
+			})
+		})
+
+		Convey("And I have an unbounded ticker producer", func() {
+			producer := producers.FromTicker(time.Millisecond)
+			producer.Attach(context)
+
+			Convey("When I close the context after a few ticks", func() {
+				readable := producer.Produce()
+				first, second := <-readable, <-readable
+				context.Close(nil)
+
+				Convey("Then ticks stop and the stream is closed gracefully", func() {
+					So(first, ShouldEqual, 0)
+					So(second, ShouldEqual, 1)
+					So(len(readable.ReadAll()), ShouldBeLessThanOrEqualTo, 1)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And I have a ticker producer with an invalid interval", func() {
+			producer := producers.FromTicker(0)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the context is closed with an error", func() {
+					So(readable.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, producers.ErrInvalidInterval)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package producers
+
+import (
+	"bufio"
+	"encoding/binary"
+	"github.com/drborges/rivers/stream"
+	"io"
+	"os"
+)
+
+// FromWAL replays records written by consumers.WriteWAL. Each record is
+// framed by a 4 bytes big endian length prefix. A torn record left behind
+// by a crash ends the replay and is truncated off the log.
+func FromWAL(path string, decode func([]byte) (stream.T, error)) stream.Producer {
+	return &Observable{
+		Capacity: 100,
+		Emit: func(emitter stream.Emitter) {
+			file, err := os.Open(path)
+			if err != nil {
+				panic(err)
+			}
+			defer file.Close()
+
+			reader := bufio.NewReader(file)
+			offset := int64(0)
+			header := make([]byte, 4)
+			for {
+				if _, err := io.ReadFull(reader, header); err != nil {
+					if err == io.ErrUnexpectedEOF {
+						truncateWAL(path, offset)
+					} else if err != io.EOF {
+						panic(err)
+					}
+					return
+				}
+
+				record := make([]byte, binary.BigEndian.Uint32(header))
+				if _, err := io.ReadFull(reader, record); err != nil {
+					if err == io.EOF || err == io.ErrUnexpectedEOF {
+						truncateWAL(path, offset)
+						return
+					}
+					panic(err)
+				}
+
+				data, err := decode(record)
+				if err != nil {
+					panic(err)
+				}
+
+				emitter.Emit(data)
+				offset += int64(len(header) + len(record))
+			}
+		},
+	}
+}
+
+func truncateWAL(path string, offset int64) {
+	if err := os.Truncate(path, offset); err != nil {
+		panic(err)
+	}
+}
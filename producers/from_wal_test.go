@@ -0,0 +1,38 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestFromWAL(t *testing.T) {
+	decode := func(record []byte) (stream.T, error) { return string(record), nil }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a write-ahead log with a torn final record", func() {
+			ioutil.WriteFile("/tmp/from_wal", []byte("\x00\x00\x00\x02hi\x00\x00\x00\x05there\x00\x00\x00\x09torn"), 0644)
+
+			Convey("When I produce data from the log", func() {
+				producer := producers.FromWAL("/tmp/from_wal", decode)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then I can read the complete records from the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{"hi", "there"})
+
+					Convey("And the torn record is truncated off the log", func() {
+						info, _ := os.Stat("/tmp/from_wal")
+						So(info.Size(), ShouldEqual, int64(15))
+					})
+				})
+			})
+		})
+	})
+}
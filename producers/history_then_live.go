@@ -0,0 +1,87 @@
+package producers
+
+import "github.com/drborges/rivers/stream"
+
+type historyThenLive struct {
+	*Observable
+	history stream.Producer
+	live    stream.Producer
+	dedup   func(stream.T) stream.T
+}
+
+// HistoryThenLive drains the history producer and then tails the live one,
+// the classic "catch up then tail" pattern. Live items arriving during the
+// replay are buffered in memory until the handoff, and live items whose key,
+// as computed by dedup, was already replayed from history are dropped, once
+// per replayed key, so the overlap window is not emitted twice.
+func HistoryThenLive(history, live stream.Producer, dedup func(stream.T) stream.T) stream.Producer {
+	producer := &historyThenLive{
+		history: history,
+		live:    live,
+		dedup:   dedup,
+	}
+	producer.Observable = &Observable{Emit: producer.emit}
+	return producer
+}
+
+func (producer *historyThenLive) Attach(context stream.Context) {
+	producer.Observable.Attach(context)
+	producer.history.Attach(context)
+	producer.live.Attach(context)
+}
+
+func (producer *historyThenLive) emit(emitter stream.Emitter) {
+	history := producer.history.Produce()
+	live := producer.live.Produce()
+	replayed := make(map[stream.T]struct{})
+	pending := []stream.T{}
+
+	for history != nil {
+		select {
+		case <-producer.context.Failure():
+			return
+		case <-producer.context.Done():
+			return
+		case data, more := <-history:
+			if !more {
+				history = nil
+				continue
+			}
+			replayed[producer.dedup(data)] = struct{}{}
+			emitter.Emit(data)
+		case data, more := <-live:
+			if !more {
+				live = nil
+				continue
+			}
+			pending = append(pending, data)
+		}
+	}
+
+	forward := func(data stream.T) {
+		key := producer.dedup(data)
+		if _, exists := replayed[key]; exists {
+			delete(replayed, key)
+			return
+		}
+		emitter.Emit(data)
+	}
+
+	for _, data := range pending {
+		forward(data)
+	}
+
+	for live != nil {
+		select {
+		case <-producer.context.Failure():
+			return
+		case <-producer.context.Done():
+			return
+		case data, more := <-live:
+			if !more {
+				return
+			}
+			forward(data)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestHistoryThenLive(t *testing.T) {
+	identity := func(data stream.T) stream.T { return data }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a history and a live source overlapping", func() {
+			history := buffered{1, 2, 3}
+			live := buffered{2, 3, 4, 5}
+
+			Convey("When I catch up on history then tail the live source", func() {
+				producer := producers.HistoryThenLive(history, live, identity)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then history is replayed first and the overlap is emitted once", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And an infinite live source", func() {
+			live := &producers.Observable{
+				Emit: func(emitter stream.Emitter) {
+					for i := 0; ; i++ {
+						emitter.Emit(i)
+					}
+				},
+			}
+
+			Convey("When I tail it and close the context", func() {
+				producer := producers.HistoryThenLive(buffered{-1}, live, identity)
+				producer.Attach(context)
+				readable := producer.Produce()
+				So(<-readable, ShouldEqual, -1)
+				context.Close(nil)
+
+				Convey("Then the stream is closed without errors", func() {
+					for range readable {
+					}
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+	})
+}
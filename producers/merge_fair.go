@@ -0,0 +1,78 @@
+package producers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"reflect"
+)
+
+type mergeFair struct {
+	*Observable
+	sources []stream.Producer
+}
+
+// MergeFair merges the streams produced by the given sources taking turns
+// between the ones with items ready, so a fast source cannot starve a slow
+// one under backpressure. Each source buffers at most its own capacity and
+// drops out once it is done; the merged stream closes when all of them are.
+func MergeFair(sources ...stream.Producer) stream.Producer {
+	merge := &mergeFair{sources: sources}
+	merge.Observable = &Observable{Emit: merge.emit}
+	return merge
+}
+
+func (merge *mergeFair) Attach(context stream.Context) {
+	merge.Observable.Attach(context)
+	for _, source := range merge.sources {
+		source.Attach(context)
+	}
+}
+
+func (merge *mergeFair) emit(emitter stream.Emitter) {
+	readables := []stream.Readable{}
+	for _, source := range merge.sources {
+		readables = append(readables, source.Produce())
+	}
+
+	for len(readables) > 0 {
+		// Give every source with an item ready one turn per round
+		ready := false
+		for i := 0; i < len(readables); {
+			select {
+			case data, more := <-readables[i]:
+				if !more {
+					readables = append(readables[:i], readables[i+1:]...)
+					continue
+				}
+				emitter.Emit(data)
+				ready = true
+			default:
+			}
+			i++
+		}
+
+		if ready || len(readables) == 0 {
+			continue
+		}
+
+		// No source has items ready, so block until any of them does
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(merge.context.Failure())},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(merge.context.Done())},
+		}
+		for _, readable := range readables {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(readable)})
+		}
+
+		chosen, data, more := reflect.Select(cases)
+		if chosen < 2 {
+			return
+		}
+
+		if !more {
+			i := chosen - 2
+			readables = append(readables[:i], readables[i+1:]...)
+			continue
+		}
+		emitter.Emit(data.Interface())
+	}
+}
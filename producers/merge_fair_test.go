@@ -0,0 +1,67 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type buffered []stream.T
+
+func (data buffered) Attach(context stream.Context) {}
+
+func (data buffered) Produce() stream.Readable {
+	readable, writable := stream.New(len(data))
+	for _, item := range data {
+		writable <- item
+	}
+	close(writable)
+	return readable
+}
+
+func TestMergeFair(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a fast and a slow source with items ready", func() {
+			fast := buffered{1, 2, 3, 4, 5, 6}
+			slow := buffered{"a", "b", "c"}
+
+			Convey("When I merge them fairly", func() {
+				producer := producers.MergeFair(fast, slow)
+				producer.Attach(context)
+				readable := producer.Produce()
+
+				Convey("Then sources take turns until they are done", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, "a", 2, "b", 3, "c", 4, 5, 6})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And an infinite source", func() {
+			infinite := &producers.Observable{
+				Emit: func(emitter stream.Emitter) {
+					for {
+						emitter.Emit(1)
+					}
+				},
+			}
+
+			Convey("When I merge it and close the context", func() {
+				producer := producers.MergeFair(infinite, producers.FromRange(1, 3))
+				producer.Attach(context)
+				readable := producer.Produce()
+				<-readable
+				context.Close(stream.Done)
+
+				Convey("Then the merged stream is closed", func() {
+					readable.ReadAll()
+					So(context.Err(), ShouldEqual, stream.Done)
+				})
+			})
+		})
+	})
+}
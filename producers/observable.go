@@ -19,8 +19,10 @@ func (observable *Observable) Produce() stream.Readable {
 	readable, writable := stream.New(observable.Capacity)
 
 	go func() {
-		defer observable.context.Recover()
+		// Recover before closing the stream so that downstream
+		// stages are aware of failures by the time they see it closed
 		defer close(writable)
+		defer observable.context.Recover()
 
 		if observable.Emit != nil {
 			observable.Emit(stream.NewEmitter(observable.context, writable))
@@ -2,8 +2,10 @@ package producers
 
 import (
 	"bufio"
+	"errors"
 	"github.com/drborges/rivers/stream"
 	"io"
+	"math/rand"
 	"os"
 	"reflect"
 )
@@ -19,6 +21,25 @@ func FromRange(from, to int) stream.Producer {
 	}
 }
 
+var ErrInvalidStep = errors.New("Range step must not be zero")
+
+// FromRangeBy emits integers from start up to end, exclusive, by step.
+// Negative steps count downward. A zero step closes the context with
+// ErrInvalidStep.
+func FromRangeBy(start, end, step int) stream.Producer {
+	return &Observable{
+		Emit: func(emitter stream.Emitter) {
+			if step == 0 {
+				panic(ErrInvalidStep)
+			}
+
+			for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+				emitter.Emit(i)
+			}
+		},
+	}
+}
+
 func FromSlice(slice stream.T) stream.Producer {
 	sv := reflect.ValueOf(slice)
 
@@ -64,6 +85,20 @@ func FromData(data ...stream.T) stream.Producer {
 	return FromSlice(data)
 }
 
+// Random emits count items built by gen out of a rng seeded with seed, so
+// the same seed yields the same stream. A negative count emits items until
+// the context is closed.
+func Random(seed int64, gen func(r *rand.Rand) stream.T, count int) stream.Producer {
+	return &Observable{
+		Emit: func(emitter stream.Emitter) {
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; count < 0 || i < count; i++ {
+				emitter.Emit(gen(rng))
+			}
+		},
+	}
+}
+
 func FromFile(f *os.File) *fromFile {
 	return &fromFile{f}
 }
@@ -0,0 +1,53 @@
+package producers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	gen := func(r *rand.Rand) stream.T { return r.Intn(1000) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And I have a seeded random producer", func() {
+			producer := producers.Random(42, gen, 5)
+			producer.Attach(context)
+
+			Convey("When I produce data", func() {
+				readable := producer.Produce()
+
+				Convey("Then the same sequence is produced for the same seed", func() {
+					rng := rand.New(rand.NewSource(42))
+					expected := []stream.T{}
+					for i := 0; i < 5; i++ {
+						expected = append(expected, rng.Intn(1000))
+					}
+
+					So(readable.ReadAll(), ShouldResemble, expected)
+				})
+			})
+		})
+
+		Convey("And I have an infinite random producer", func() {
+			producer := producers.Random(42, gen, -1)
+			producer.Attach(context)
+
+			Convey("When I close the context while producing data", func() {
+				readable := producer.Produce()
+				<-readable
+				context.Close(stream.Done)
+
+				Convey("Then the stream is closed", func() {
+					readable.ReadAll()
+					So(context.Err(), ShouldEqual, stream.Done)
+				})
+			})
+		})
+	})
+}
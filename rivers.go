@@ -1,16 +1,29 @@
 package rivers
 
 import (
+	"database/sql"
 	"github.com/drborges/rivers/combiners"
 	"github.com/drborges/rivers/consumers"
 	"github.com/drborges/rivers/dispatchers"
 	"github.com/drborges/rivers/producers"
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
+	"hash"
 	"io"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"regexp"
 	"time"
 )
 
+const DefaultRoute = "default"
+
+type RegexRule struct {
+	Name    string
+	Pattern string
+}
+
 type Pipeline struct {
 	Context  stream.Context
 	Stream   stream.Readable
@@ -31,10 +44,18 @@ func FromRange(from, to int) *Pipeline {
 	return From(producers.FromRange(from, to))
 }
 
+func FromRangeBy(start, end, step int) *Pipeline {
+	return From(producers.FromRangeBy(start, end, step))
+}
+
 func FromReader(r io.Reader) *Pipeline {
 	return From(producers.FromReader(r))
 }
 
+func FromLines(r io.Reader, options ...producers.LinesOption) *Pipeline {
+	return From(producers.FromLines(r, options...))
+}
+
 func FromData(data ...stream.T) *Pipeline {
 	return From(producers.FromData(data...))
 }
@@ -43,6 +64,42 @@ func FromSlice(slice stream.T) *Pipeline {
 	return From(producers.FromSlice(slice))
 }
 
+func FromRandom(seed int64, gen func(r *rand.Rand) stream.T, count int) *Pipeline {
+	return From(producers.Random(seed, gen, count))
+}
+
+// FromChannel builds a pipeline reading from ch until it is closed or the
+// pipeline's context is, see producers.FromChannel
+func FromChannel(ch <-chan stream.T) *Pipeline {
+	return From(producers.FromChannel(ch))
+}
+
+func FromTicker(interval time.Duration) *Pipeline {
+	return From(producers.FromTicker(interval))
+}
+
+func FromTickerN(interval time.Duration, count int) *Pipeline {
+	return From(producers.FromTickerN(interval, count))
+}
+
+func FromMessageSource(src producers.MessageSource) *Pipeline {
+	return From(producers.FromMessageSource(src))
+}
+
+func FromHistoryThenLive(history, live stream.Producer, dedup func(stream.T) stream.T) *Pipeline {
+	return From(producers.HistoryThenLive(history, live, dedup))
+}
+
+func FromCheckpointedCursor(store producers.CheckpointStore, fetch producers.FetchFn) *Pipeline {
+	return From(producers.CheckpointedCursor(store, fetch))
+}
+
+// Err returns the error the pipeline was closed with, nil while
+// the pipeline is still running or when it finishes gracefully
+func (pipeline *Pipeline) Err() error {
+	return pipeline.Context.Err()
+}
+
 func (pipeline *Pipeline) Parallel() *Pipeline {
 	pipeline.parallel = true
 	return pipeline
@@ -75,13 +132,39 @@ func (pipeline *Pipeline) SplitN(n int) []*Pipeline {
 }
 
 func (pipeline *Pipeline) Partition(fn stream.PredicateFn) (*Pipeline, *Pipeline) {
-	lhsIn, lhsOut := stream.New(pipeline.Stream.Capacity())
-	rhsIn := dispatchers.New(pipeline.Context).If(fn).Dispatch(pipeline.Stream, lhsOut)
+	partition := transformers.Partition(fn)
+	partition.Attach(pipeline.Context)
+	lhsIn, rhsIn := partition.Partition(pipeline.Stream)
 	lhsPipeline := &Pipeline{Context: pipeline.Context, Stream: lhsIn, parallel: pipeline.parallel}
 	rhsPipeline := &Pipeline{Context: pipeline.Context, Stream: rhsIn, parallel: pipeline.parallel}
 	return lhsPipeline, rhsPipeline
 }
 
+// RouteByRegex routes each string item to the pipeline of the first rule
+// whose pattern matches it, items matching no rule go to DefaultRoute
+func (pipeline *Pipeline) RouteByRegex(rules []RegexRule) (map[string]*Pipeline, error) {
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		patterns[i] = pattern
+	}
+
+	routes := make(map[string]*Pipeline)
+	rest := pipeline
+	for i, rule := range rules {
+		pattern := patterns[i]
+		routes[rule.Name], rest = rest.Partition(func(data stream.T) bool {
+			text, ok := data.(string)
+			return ok && pattern.MatchString(text)
+		})
+	}
+	routes[DefaultRoute] = rest
+	return routes, nil
+}
+
 func (pipeline *Pipeline) Dispatch(writables ...stream.Writable) *Pipeline {
 	return &Pipeline{
 		Context:  pipeline.Context,
@@ -99,17 +182,53 @@ func (pipeline *Pipeline) DispatchIf(fn stream.PredicateFn, writables ...stream.
 }
 
 func (pipeline *Pipeline) Merge(pipelines ...*Pipeline) *Pipeline {
-	return pipeline.Combine(combiners.FIFO(), pipelines)
+	return pipeline.Combine(combiners.Merge(), pipelines)
+}
+
+func (pipeline *Pipeline) Concat(pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.Concat(), pipelines)
+}
+
+func (pipeline *Pipeline) Race(pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.Race(), pipelines)
 }
 
 func (pipeline *Pipeline) Zip(pipelines ...*Pipeline) *Pipeline {
 	return pipeline.Combine(combiners.Zip(), pipelines)
 }
 
+func (pipeline *Pipeline) ZipPadded(pad stream.T, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.ZipPadded(pad), pipelines)
+}
+
 func (pipeline *Pipeline) ZipBy(fn stream.ReduceFn, pipelines ...*Pipeline) *Pipeline {
 	return pipeline.Combine(combiners.ZipBy(fn), pipelines)
 }
 
+func (pipeline *Pipeline) ZipWith(fn func(items ...stream.T) stream.T, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.ZipWith(fn), pipelines)
+}
+
+func (pipeline *Pipeline) CombineLatest(fn func(latest ...stream.T) stream.T, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.CombineLatest(fn), pipelines)
+}
+
+func (pipeline *Pipeline) WithLatestFrom(pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.WithLatestFrom(), pipelines)
+}
+
+func (pipeline *Pipeline) ZipWithIndex(fn func(index int, items ...stream.T) stream.T, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.ZipWithIndex(fn), pipelines)
+}
+
+func (pipeline *Pipeline) MergeSortedStable(less stream.SortByFn, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.MergeSortedStable(less), pipelines)
+}
+
+func (pipeline *Pipeline) Union(key stream.MapFn, pipelines ...*Pipeline) *Pipeline {
+	return pipeline.Combine(combiners.Union(key), pipelines)
+}
+
 func (pipeline *Pipeline) Combine(combiner stream.Combiner, pipelines []*Pipeline) *Pipeline {
 	combiner.Attach(pipeline.Context)
 
@@ -149,10 +268,51 @@ func (pipeline *Pipeline) ApplyParallel(transformer stream.Transformer) *Pipelin
 	return pipeline.Apply(transformer).Merge(parallelPipelines...)
 }
 
+// OnError degrades a failing pipeline into a fallback item built by
+// handler out of the failure, the returned pipeline has its own context
+// and so completes gracefully, see transformers.OnError
+func (pipeline *Pipeline) OnError(handler func(error) stream.T) *Pipeline {
+	downstream := NewContext()
+	downstream.SetDeadline(pipeline.Context.Deadline())
+
+	transformer := transformers.OnError(handler, downstream)
+	transformer.Attach(pipeline.Context)
+
+	return &Pipeline{
+		Context:  downstream,
+		Stream:   transformer.Transform(pipeline.Stream),
+		parallel: pipeline.parallel,
+	}
+}
+
 func (pipeline *Pipeline) Filter(fn stream.PredicateFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Filter(fn))
 }
 
+func (pipeline *Pipeline) SampleProbability(p float64, rng *rand.Rand) *Pipeline {
+	return pipeline.ApplyParallel(transformers.SampleProbability(p, rng))
+}
+
+func (pipeline *Pipeline) BloomDedup(expectedN int, falsePositiveRate float64, key func(stream.T) string) *Pipeline {
+	return pipeline.ApplyParallel(transformers.BloomDedup(expectedN, falsePositiveRate, key))
+}
+
+func (pipeline *Pipeline) Distinct() *Pipeline {
+	return pipeline.ApplyParallel(transformers.Distinct())
+}
+
+func (pipeline *Pipeline) DistinctBy(key func(stream.T) stream.T) *Pipeline {
+	return pipeline.ApplyParallel(transformers.DistinctBy(key))
+}
+
+func (pipeline *Pipeline) DedupConsecutive() *Pipeline {
+	return pipeline.Apply(transformers.DedupConsecutive())
+}
+
+func (pipeline *Pipeline) DedupConsecutiveBy(key func(stream.T) stream.T) *Pipeline {
+	return pipeline.Apply(transformers.DedupConsecutiveBy(key))
+}
+
 func (pipeline *Pipeline) OnData(fn stream.OnDataFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.OnData(fn))
 }
@@ -161,10 +321,59 @@ func (pipeline *Pipeline) Map(fn stream.MapFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Map(fn))
 }
 
+func (pipeline *Pipeline) TimeoutPerItem(timeout time.Duration, fn stream.MapFn) *Pipeline {
+	return pipeline.ApplyParallel(transformers.TimeoutPerItem(timeout, fn))
+}
+
+// MapParallel maps items across workers preserving their order, unlike
+// Parallel().Map(fn) whose output order is not guaranteed
+func (pipeline *Pipeline) MapParallel(workers int, fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.MapParallel(workers, fn))
+}
+
+func (pipeline *Pipeline) Speculate(fn func(stream.T) (stream.T, error), after time.Duration, maxInflight int) *Pipeline {
+	return pipeline.ApplyParallel(transformers.Speculate(fn, after, maxInflight))
+}
+
+func (pipeline *Pipeline) Retry(attempts int, backoff time.Duration, fn func(stream.T) (stream.T, error)) *Pipeline {
+	return pipeline.ApplyParallel(transformers.Retry(attempts, fn).Backoff(backoff))
+}
+
+func (pipeline *Pipeline) RetryWithPolicy(fn func(stream.T) (stream.T, error), policy transformers.RetryPolicy) *Pipeline {
+	return pipeline.ApplyParallel(transformers.RetryWithPolicy(fn, policy))
+}
+
+// WindowJoin joins the pipeline with right, see transformers.WindowJoin.
+// The right pipeline is consumed within this pipeline's context.
+func (pipeline *Pipeline) WindowJoin(right *Pipeline, leftKey, rightKey stream.MapFn, leftTs, rightTs func(stream.T) time.Time, window time.Duration, merge func(l, r stream.T) stream.T) *Pipeline {
+	return pipeline.Apply(transformers.WindowJoin(right.Stream, leftKey, rightKey, leftTs, rightTs, window, merge))
+}
+
+// Join inner joins the pipeline with right, see combiners.Join
+func (pipeline *Pipeline) Join(right *Pipeline, key stream.MapFn, combine func(a, b stream.T) stream.T) *Pipeline {
+	return pipeline.Combine(combiners.Join(key, combine), []*Pipeline{right})
+}
+
+func (pipeline *Pipeline) StreamingLookupJoin(fetch func(key stream.T) (stream.T, error), cacheSize int, key stream.MapFn, merge func(item, dim stream.T) stream.T) *Pipeline {
+	return pipeline.ApplyParallel(transformers.StreamingLookupJoin(fetch, cacheSize, key, merge))
+}
+
+func (pipeline *Pipeline) Coerce(schema map[string]reflect.Kind) *Pipeline {
+	return pipeline.ApplyParallel(transformers.Coerce(schema))
+}
+
 func (pipeline *Pipeline) FlatMap(fn stream.MapFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Map(fn)).Flatten()
 }
 
+func (pipeline *Pipeline) FlatMapParallel(maxConcurrent int, fn func(stream.T) stream.Producer) *Pipeline {
+	return pipeline.Apply(transformers.FlatMapParallel(maxConcurrent, fn))
+}
+
+func (pipeline *Pipeline) Tap(fn stream.EachFn) *Pipeline {
+	return pipeline.ApplyParallel(transformers.Tap(fn))
+}
+
 func (pipeline *Pipeline) Each(fn stream.EachFn) *Pipeline {
 	return pipeline.ApplyParallel(transformers.Each(fn))
 }
@@ -183,6 +392,18 @@ func (pipeline *Pipeline) TakeFirst(n int) *Pipeline {
 	return pipeline.Apply(transformers.TakeFirst(n))
 }
 
+func (pipeline *Pipeline) Limit(n int) *Pipeline {
+	return pipeline.ApplyParallel(transformers.Limit(n))
+}
+
+func (pipeline *Pipeline) TakeWhile(fn stream.PredicateFn) *Pipeline {
+	return pipeline.Apply(transformers.TakeWhile(fn))
+}
+
+func (pipeline *Pipeline) DropWhile(fn stream.PredicateFn) *Pipeline {
+	return pipeline.Apply(transformers.DropWhile(fn))
+}
+
 func (pipeline *Pipeline) Take(fn stream.PredicateFn) *Pipeline {
 	return pipeline.Filter(fn)
 }
@@ -192,25 +413,179 @@ func (pipeline *Pipeline) DropFirst(n int) *Pipeline {
 }
 
 func (pipeline *Pipeline) Drop(fn stream.PredicateFn) *Pipeline {
-	return pipeline.Take(func(data stream.T) bool { return !fn(data) })
+	return pipeline.ApplyParallel(transformers.FilterNot(fn))
 }
 
 func (pipeline *Pipeline) Reduce(acc stream.T, fn stream.ReduceFn) *Pipeline {
 	return pipeline.Apply(transformers.Reduce(acc, fn))
 }
 
+func (pipeline *Pipeline) Scan(acc stream.T, fn stream.ReduceFn) *Pipeline {
+	return pipeline.Apply(transformers.Scan(acc, fn))
+}
+
+func (pipeline *Pipeline) ReverseBuffered() *Pipeline {
+	return pipeline.Apply(transformers.ReverseBuffered())
+}
+
 func (pipeline *Pipeline) Flatten() *Pipeline {
 	return pipeline.ApplyParallel(transformers.Flatten())
 }
 
+func (pipeline *Pipeline) FlattenStrict() *Pipeline {
+	return pipeline.ApplyParallel(transformers.FlattenStrict())
+}
+
 func (pipeline *Pipeline) Batch(size int) *Pipeline {
 	return pipeline.Apply(transformers.Batch(size))
 }
 
+// WithIndex wraps items in a transformers.Indexed, not to be confused with
+// ZipWithIndex which zips several pipelines
+func (pipeline *Pipeline) WithIndex() *Pipeline {
+	return pipeline.Apply(transformers.ZipWithIndex())
+}
+
+func (pipeline *Pipeline) StartWith(items ...stream.T) *Pipeline {
+	return pipeline.Apply(transformers.StartWith(items...))
+}
+
+func (pipeline *Pipeline) EndWith(items ...stream.T) *Pipeline {
+	return pipeline.Apply(transformers.EndWith(items...))
+}
+
+func (pipeline *Pipeline) DefaultIfEmpty(value stream.T) *Pipeline {
+	return pipeline.Apply(transformers.DefaultIfEmpty(value))
+}
+
+func (pipeline *Pipeline) ElementAt(n int) *Pipeline {
+	return pipeline.Apply(transformers.ElementAt(n))
+}
+
+func (pipeline *Pipeline) Pairwise() *Pipeline {
+	return pipeline.Apply(transformers.Pairwise())
+}
+
+func (pipeline *Pipeline) WindowTumbling(size int) *Pipeline {
+	return pipeline.Apply(transformers.WindowTumbling(size))
+}
+
+func (pipeline *Pipeline) WindowSliding(size, step int) *Pipeline {
+	return pipeline.Apply(transformers.WindowSliding(size, step))
+}
+
 func (pipeline *Pipeline) BatchBy(batch stream.Batch) *Pipeline {
 	return pipeline.Apply(transformers.BatchBy(batch))
 }
 
+func (pipeline *Pipeline) BatchBytes(maxBytes int, size func(stream.T) int) *Pipeline {
+	return pipeline.Apply(transformers.BatchBytes(maxBytes, size))
+}
+
+func (pipeline *Pipeline) UploadChunks(chunkBytes int, size func(stream.T) int) *Pipeline {
+	return pipeline.Apply(transformers.UploadChunks(chunkBytes, size))
+}
+
+func (pipeline *Pipeline) TopKByKey(key func(stream.T) stream.T, k int, less func(a, b stream.T) bool) *Pipeline {
+	return pipeline.Apply(transformers.TopKByKey(key, k, less))
+}
+
+func (pipeline *Pipeline) UngroupWithKey() *Pipeline {
+	return pipeline.ApplyParallel(transformers.UngroupWithKey())
+}
+
+func (pipeline *Pipeline) LSHBucket(hash func(stream.T) uint64, bands int) *Pipeline {
+	return pipeline.ApplyParallel(transformers.LSHBucket(hash, bands))
+}
+
+func (pipeline *Pipeline) CoalesceRanges(start, end func(stream.T) int64, build func(start, end int64) stream.T) *Pipeline {
+	return pipeline.Apply(transformers.CoalesceRanges(start, end, build))
+}
+
+func (pipeline *Pipeline) MatchSequence(patterns []stream.PredicateFn, onMatch func([]stream.T) stream.T) *Pipeline {
+	return pipeline.Apply(transformers.MatchSequence(patterns, onMatch))
+}
+
+func (pipeline *Pipeline) Hysteresis(on stream.PredicateFn, offAfter int) *Pipeline {
+	return pipeline.Apply(transformers.Hysteresis(on, offAfter))
+}
+
+func (pipeline *Pipeline) OrderedByKey(key stream.MapFn, workers int, fn stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.OrderedByKey(key, workers, fn))
+}
+
+func (pipeline *Pipeline) RateLimitByKey(key stream.MapFn, rate float64, burst int) *Pipeline {
+	return pipeline.Apply(transformers.RateLimitByKey(key, rate, burst))
+}
+
+func (pipeline *Pipeline) Watermark(timestamp func(stream.T) time.Time, maxLateness time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.Watermark(timestamp, maxLateness))
+}
+
+func (pipeline *Pipeline) ZScoreFilter(value func(stream.T) float64, threshold float64, window int) *Pipeline {
+	return pipeline.Apply(transformers.ZScoreFilter(value, threshold, window))
+}
+
+func (pipeline *Pipeline) Around(onFirst, onLast stream.EachFn) *Pipeline {
+	return pipeline.Apply(transformers.Around(onFirst, onLast))
+}
+
+func (pipeline *Pipeline) ApproxDistinctCount(window time.Duration, key func(stream.T) string) *Pipeline {
+	return pipeline.Apply(transformers.ApproxDistinctCount(window, key))
+}
+
+func (pipeline *Pipeline) Delay(d time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.Delay(d))
+}
+
+func (pipeline *Pipeline) Throttle(interval time.Duration) *Pipeline {
+	return pipeline.ApplyParallel(transformers.Throttle(interval))
+}
+
+func (pipeline *Pipeline) Debounce(quiet time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.Debounce(quiet))
+}
+
+func (pipeline *Pipeline) BufferTime(interval time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.BufferTime(interval))
+}
+
+func (pipeline *Pipeline) GroupStreams(key stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.GroupBy(key))
+}
+
+func (pipeline *Pipeline) WindowStreams(window time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.WindowStreams(window))
+}
+
+func (pipeline *Pipeline) DiffAgainst(prior map[stream.T]stream.T, key, value stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.DiffAgainst(prior, key, value))
+}
+
+func (pipeline *Pipeline) CreditFlow(credits <-chan int) *Pipeline {
+	return pipeline.Apply(transformers.CreditFlow(credits))
+}
+
+func (pipeline *Pipeline) Snapshot(reduce stream.ReduceFn, seed func() stream.T, interval time.Duration) *Pipeline {
+	return pipeline.Apply(transformers.Snapshot(reduce, seed, interval))
+}
+
+func (pipeline *Pipeline) DecayingCount(halfLife time.Duration, key stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.DecayingCount(halfLife, key))
+}
+
+func (pipeline *Pipeline) AppendOnComplete(sentinel func() stream.T) *Pipeline {
+	return pipeline.Apply(transformers.AppendOnComplete(sentinel))
+}
+
+func (pipeline *Pipeline) Progress(total int, report func(percent float64)) *Pipeline {
+	return pipeline.Apply(transformers.Progress(total, report))
+}
+
+func (pipeline *Pipeline) Pullable() *stream.Puller {
+	return stream.NewPuller(pipeline.Context, pipeline.Stream)
+}
+
 func (pipeline *Pipeline) Then(consumer stream.Consumer) error {
 	consumer.Attach(pipeline.Context)
 	consumer.Consume(pipeline.Stream)
@@ -248,6 +623,76 @@ func (pipeline *Pipeline) CollectBy(fn stream.EachFn) error {
 	return pipeline.Then(consumers.CollectBy(fn))
 }
 
+func (pipeline *Pipeline) Checksum(h hash.Hash, bytesOf func(stream.T) []byte) ([]byte, error) {
+	if err := pipeline.Then(consumers.Checksum(h, bytesOf)); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+func (pipeline *Pipeline) ForEachParallel(workers int, fn func(stream.T) error) error {
+	return pipeline.Then(consumers.ForEachParallel(workers, fn))
+}
+
+// ForEachCollectingErrors runs fn on every item regardless of its errors,
+// reporting them along with the pipeline's own error, if any
+func (pipeline *Pipeline) ForEachCollectingErrors(fn func(stream.T) error) (int, []error) {
+	collector := consumers.ForEachCollectingErrors(fn)
+	if err := pipeline.Then(collector); err != nil {
+		return collector.Processed(), append(collector.Errors(), err)
+	}
+	return collector.Processed(), collector.Errors()
+}
+
+func (pipeline *Pipeline) Shadow(primary, shadow func(stream.T) error) error {
+	return pipeline.Then(consumers.Shadow(primary, shadow))
+}
+
+func (pipeline *Pipeline) SendGRPC(send func(stream.T) error) error {
+	return pipeline.Then(consumers.SendGRPC(send))
+}
+
+func (pipeline *Pipeline) WriteWAL(path string, encode func(stream.T) ([]byte, error)) error {
+	return pipeline.Then(consumers.WriteWAL(path, encode))
+}
+
+func (pipeline *Pipeline) WriteSQL(db *sql.DB, batchSize int, exec func(tx *sql.Tx, items []stream.T) error) error {
+	return pipeline.Then(consumers.WriteSQL(db, batchSize, exec))
+}
+
+func (pipeline *Pipeline) WriteObjects(store consumers.ObjectStore, keyFor func(stream.T) string, encode func(stream.T) ([]byte, error)) error {
+	return pipeline.Then(consumers.WriteObjects(store, keyFor, encode))
+}
+
+func (pipeline *Pipeline) WriteSSE(w http.ResponseWriter, r *http.Request, encode func(stream.T) (event string, data []byte, err error)) error {
+	return pipeline.Then(consumers.WriteSSE(w, r, encode))
+}
+
+// Sum emits the sum of the numeric items in the pipeline, see transformers.Sum
+func (pipeline *Pipeline) Sum() *Pipeline {
+	return pipeline.Apply(transformers.Sum())
+}
+
+// Min emits the smallest item in the pipeline according to less
+func (pipeline *Pipeline) Min(less stream.SortByFn) *Pipeline {
+	return pipeline.Apply(transformers.Min(less))
+}
+
+// Max emits the largest item in the pipeline according to less
+func (pipeline *Pipeline) Max(less stream.SortByFn) *Pipeline {
+	return pipeline.Apply(transformers.Max(less))
+}
+
+// Sorted sorts the items in the pipeline, unlike SortBy it does not
+// consume the pipeline, see transformers.Sort
+func (pipeline *Pipeline) Sorted(less stream.SortByFn) *Pipeline {
+	return pipeline.Apply(transformers.Sort(less))
+}
+
+func (pipeline *Pipeline) SortedByKey(key stream.MapFn) *Pipeline {
+	return pipeline.Apply(transformers.SortBy(key))
+}
+
 func (pipeline *Pipeline) SortBy(fn stream.SortByFn) ([]stream.T, error) {
 	items, err := pipeline.Collect()
 
@@ -264,9 +709,15 @@ func (pipeline *Pipeline) GroupBy(groupFn stream.MapFn) (stream.Groups, error) {
 	return result, pipeline.Then(consumers.GroupBy(groupFn, result))
 }
 
+func (pipeline *Pipeline) BuildIndex(terms func(stream.T) []string) (map[string][]stream.T, error) {
+	index := make(map[string][]stream.T)
+	return index, pipeline.Then(consumers.BuildIndex(terms, index))
+}
+
 func (pipeline *Pipeline) Count() (int, error) {
-	items, err := pipeline.Collect()
-	return len(items), err
+	var count int
+	err := pipeline.Apply(transformers.Count()).CollectLastAs(&count)
+	return count, err
 }
 
 func (pipeline *Pipeline) Drain() error {
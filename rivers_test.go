@@ -2,6 +2,8 @@ package rivers_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"errors"
 	"github.com/drborges/rivers"
 	"github.com/drborges/rivers/producers"
 	"github.com/drborges/rivers/stream"
@@ -139,6 +141,39 @@ func TestRiversAPI(t *testing.T) {
 			So(odds, ShouldContain, 3)
 		})
 
+		Convey("From Range -> Partition -> Collect one side only", func() {
+			evensStage, _ := rivers.FromRange(1, 100).Partition(evensOnly)
+			evens, err := evensStage.Collect()
+
+			So(err, ShouldBeNil)
+			So(evens, ShouldHaveLength, 50)
+		})
+
+		Convey("From Data -> Route By Regex", func() {
+			routes, err := rivers.FromData("ERROR disk", "INFO boot", "WARN cpu", "ERROR net").RouteByRegex([]rivers.RegexRule{
+				{Name: "errors", Pattern: "^ERROR"},
+				{Name: "warnings", Pattern: "^WARN"},
+			})
+
+			So(err, ShouldBeNil)
+
+			errs, _ := routes["errors"].Collect()
+			warnings, _ := routes["warnings"].Collect()
+			others, _ := routes[rivers.DefaultRoute].Collect()
+
+			So(len(errs), ShouldEqual, 2)
+			So(errs, ShouldContain, "ERROR disk")
+			So(errs, ShouldContain, "ERROR net")
+			So(warnings, ShouldResemble, []stream.T{"WARN cpu"})
+			So(others, ShouldResemble, []stream.T{"INFO boot"})
+		})
+
+		Convey("From Data -> Route By Invalid Regex", func() {
+			_, err := rivers.FromData("a").RouteByRegex([]rivers.RegexRule{{Name: "invalid", Pattern: "("}})
+
+			So(err, ShouldNotBeNil)
+		})
+
 		Convey("From Range -> Slipt", func() {
 			lhs, rhs := rivers.FromRange(1, 2).Split()
 
@@ -202,6 +237,19 @@ func TestRiversAPI(t *testing.T) {
 			So(pipeline.Stream.ReadAll(), ShouldResemble, []stream.T{3, 4, 5})
 		})
 
+		Convey("From Range -> Drop First more than available", func() {
+			pipeline := rivers.FromRange(1, 2).DropFirst(5)
+
+			So(pipeline.Stream.ReadAll(), ShouldBeEmpty)
+		})
+
+		Convey("From Range -> Take First 0", func() {
+			data, err := rivers.FromRange(1, 5).TakeFirst(0).Collect()
+
+			So(err, ShouldBeNil)
+			So(data, ShouldBeEmpty)
+		})
+
 		Convey("From Range -> Collect", func() {
 			data, err := rivers.FromRange(1, 4).Collect()
 
@@ -298,6 +346,16 @@ func TestRiversAPI(t *testing.T) {
 			So(end.Seconds(), ShouldBeLessThanOrEqualTo, 1)
 		})
 
+		Convey("From Range -> Parallel -> Map -> Limit", func() {
+			data, err := rivers.FromRange(1, 50).Parallel().Map(func(data stream.T) stream.T {
+				time.Sleep(time.Millisecond)
+				return data
+			}).Limit(10).Collect()
+
+			So(err, ShouldBeNil)
+			So(len(data), ShouldEqual, 10)
+		})
+
 		Convey("From Slow Producer -> Find", func() {
 			slowProducer := &producers.Observable{
 				Capacity: 2,
@@ -378,6 +436,81 @@ func TestRiversAPI(t *testing.T) {
 			So(items, ShouldResemble, []stream.T{"a", "b", "c", "d"})
 		})
 
+		Convey("From Range -> Err", func() {
+			succeeding := rivers.FromRange(1, 3).Map(add(1))
+			succeeding.Stream.ReadAll()
+			So(succeeding.Err(), ShouldBeNil)
+
+			failure := errors.New("failure")
+			failing := rivers.FromRange(1, 3).Map(func(data stream.T) stream.T {
+				if data == 2 {
+					panic(failure)
+				}
+				return data
+			})
+
+			failing.Stream.ReadAll()
+			So(failing.Err(), ShouldEqual, failure)
+		})
+
+		Convey("From Range -> Pullable", func() {
+			puller := rivers.FromRange(1, 2).Pullable()
+
+			first, more, err := puller.Next()
+			So(first, ShouldEqual, 1)
+			So(more, ShouldBeTrue)
+			So(err, ShouldBeNil)
+
+			second, more, err := puller.Next()
+			So(second, ShouldEqual, 2)
+			So(more, ShouldBeTrue)
+			So(err, ShouldBeNil)
+
+			_, more, err = puller.Next()
+			So(more, ShouldBeFalse)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("From Infinite Producer -> Pullable -> Stop", func() {
+			stopped := make(chan bool)
+			producer := &producers.Observable{
+				Emit: func(emitter stream.Emitter) {
+					defer close(stopped)
+					for i := 0; ; i++ {
+						emitter.Emit(i)
+					}
+				},
+			}
+
+			puller := rivers.From(producer).Pullable()
+			data, _, _ := puller.Next()
+			puller.Stop()
+
+			So(data, ShouldEqual, 0)
+			So(<-stopped, ShouldBeFalse)
+		})
+
+		Convey("From Channel -> Map", func() {
+			ch := make(chan stream.T, 2)
+			ch <- 1
+			ch <- 2
+			close(ch)
+
+			data, err := rivers.FromChannel(ch).Map(add(1)).Collect()
+
+			So(err, ShouldBeNil)
+			So(data, ShouldResemble, []stream.T{2, 3})
+		})
+
+		Convey("From Range -> Checksum", func() {
+			bytesOf := func(data stream.T) []byte { return []byte{byte(data.(int))} }
+			first, err := rivers.FromRange(1, 5).Checksum(sha256.New(), bytesOf)
+			second, _ := rivers.FromRange(1, 5).Checksum(sha256.New(), bytesOf)
+
+			So(err, ShouldBeNil)
+			So(first, ShouldResemble, second)
+		})
+
 		Convey("From Range -> Count", func() {
 			count, err := rivers.FromRange(1, 5).Count()
 
@@ -20,6 +20,17 @@ func (emitter *emitter) Emit(data T) {
 	case <-time.After(emitter.context.Deadline()):
 		panic(Timeout)
 	default:
-		emitter.writable <- data
+	}
+
+	// Keeps watching the context while blocked on a full stream, so
+	// closing it releases producers nobody reads from anymore
+	select {
+	case <-emitter.context.Done():
+		panic(Done)
+	case <-emitter.context.Failure():
+		panic(Done)
+	case <-time.After(emitter.context.Deadline()):
+		panic(Timeout)
+	case emitter.writable <- data:
 	}
 }
@@ -0,0 +1,34 @@
+package stream
+
+import "time"
+
+type Puller struct {
+	context  Context
+	readable Readable
+}
+
+func NewPuller(context Context, readable Readable) *Puller {
+	return &Puller{context, readable}
+}
+
+// Next blocks until the next item is available, returning false once the
+// stream is over along with the error the context was closed with, if any
+func (puller *Puller) Next() (T, bool, error) {
+	select {
+	case <-puller.context.Failure():
+		return nil, false, puller.context.Err()
+	case <-time.After(puller.context.Deadline()):
+		puller.context.Close(Timeout)
+		return nil, false, Timeout
+	case data, more := <-puller.readable:
+		if !more {
+			return nil, false, puller.context.Err()
+		}
+		return data, true, nil
+	}
+}
+
+// Stop tells upstream stages to shutdown without errors
+func (puller *Puller) Stop() {
+	puller.context.Close(nil)
+}
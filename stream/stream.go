@@ -1,10 +1,41 @@
 package stream
 
+import "time"
+
 func New(capacity int) (Readable, Writable) {
 	ch := make(chan T, capacity)
 	return ch, ch
 }
 
+// FromChannel forwards items from ch into a new stream until ch is closed
+// or the context fails or is done, closing the stream afterwards. ch is
+// never closed by FromChannel: closing it remains up to its owner.
+func FromChannel(context Context, ch <-chan T) Readable {
+	readable, writable := New(cap(ch))
+
+	go func() {
+		defer close(writable)
+		defer context.Recover()
+
+		emitter := NewEmitter(context, writable)
+		for {
+			select {
+			case <-context.Failure():
+				return
+			case <-context.Done():
+				return
+			case data, more := <-ch:
+				if !more {
+					return
+				}
+				emitter.Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
+
 func (readable Readable) ReadAll() []T {
 	read := []T{}
 	for data := range readable {
@@ -13,6 +44,27 @@ func (readable Readable) ReadAll() []T {
 	return read
 }
 
+// Collect drains the readable stream observing the given context's deadline
+// and failure signals, returning the items read so far along with the error
+// the context was closed with, if any.
+func (readable Readable) Collect(context Context) ([]T, error) {
+	read := []T{}
+	for {
+		select {
+		case <-context.Failure():
+			return read, context.Err()
+		case <-time.After(context.Deadline()):
+			context.Close(Timeout)
+			return read, Timeout
+		case data, more := <-readable:
+			if !more {
+				return read, context.Err()
+			}
+			read = append(read, data)
+		}
+	}
+}
+
 func (readable Readable) Capacity() int {
 	return cap(readable)
 }
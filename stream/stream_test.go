@@ -0,0 +1,126 @@
+package stream_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestCollect(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a closed stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+			close(out)
+
+			Convey("When I collect the stream", func() {
+				items, err := in.Collect(context)
+
+				Convey("Then all items are collected without errors", func() {
+					So(err, ShouldBeNil)
+					So(items, ShouldResemble, []stream.T{1, 2})
+				})
+			})
+		})
+
+		Convey("And a stream that never closes", func() {
+			in, out := stream.New(2)
+			out <- 1
+
+			Convey("When I collect the stream past the context deadline", func() {
+				context.SetDeadline(100 * time.Millisecond)
+				items, err := in.Collect(context)
+
+				Convey("Then the items read so far are returned with a timeout error", func() {
+					So(err, ShouldEqual, stream.Timeout)
+					So(items, ShouldResemble, []stream.T{1})
+				})
+			})
+
+			Convey("When the context fails while collecting", func() {
+				failure := errors.New("failure")
+				go func() {
+					time.Sleep(50 * time.Millisecond)
+					context.Close(failure)
+				}()
+				items, err := in.Collect(context)
+
+				Convey("Then the failure is returned", func() {
+					So(err, ShouldEqual, failure)
+					So(items, ShouldResemble, []stream.T{1})
+				})
+			})
+		})
+	})
+}
+
+func TestEmitter(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And an emitter blocked on a full stream", func() {
+			_, writable := stream.New(0)
+			emitter := stream.NewEmitter(context, writable)
+
+			recovered := make(chan interface{})
+			go func() {
+				defer func() { recovered <- recover() }()
+				emitter.Emit(1)
+			}()
+
+			Convey("When I close the context", func() {
+				context.Close(nil)
+
+				Convey("Then the emitter is released", func() {
+					So(<-recovered, ShouldEqual, stream.Done)
+				})
+			})
+		})
+	})
+}
+
+func TestFromChannel(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a channel of data", func() {
+			Convey("When I adopt the channel and its owner closes it", func() {
+				ch := make(chan stream.T, 2)
+				readable := stream.FromChannel(context, ch)
+				ch <- 1
+				ch <- 2
+				close(ch)
+
+				Convey("Then all items are forwarded to the stream", func() {
+					So(readable.ReadAll(), ShouldResemble, []stream.T{1, 2})
+				})
+			})
+
+			Convey("When I adopt the channel and close the context", func() {
+				ch := make(chan stream.T)
+				readable := stream.FromChannel(context, ch)
+				ch <- 1
+				context.Close(nil)
+
+				Convey("Then the stream is closed and the channel is no longer read", func() {
+					readable.ReadAll()
+
+					// sending on ch would panic had it been closed
+					read := false
+					select {
+					case ch <- 2:
+						read = true
+					default:
+					}
+					So(read, ShouldBeFalse)
+				})
+			})
+		})
+	})
+}
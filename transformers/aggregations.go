@@ -0,0 +1,90 @@
+package transformers
+
+import (
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"reflect"
+)
+
+// Count emits the number of items seen once upstream completes
+func Count() stream.Transformer {
+	count := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			count++
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			emitter.Emit(count)
+		},
+	}
+}
+
+// Sum emits the sum of numeric items once upstream completes, typed after
+// them, or the int 0 for empty streams. Items of a type other than the
+// first one's close the context with an error.
+func Sum() stream.Transformer {
+	var sum reflect.Value
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			value := reflect.ValueOf(data)
+			if !value.IsValid() {
+				return fmt.Errorf("Cannot sum %v", data)
+			}
+
+			if !sum.IsValid() {
+				sum = reflect.New(value.Type()).Elem()
+			}
+
+			if value.Type() != sum.Type() {
+				return fmt.Errorf("Cannot sum %v of type %T to a sum of type %v", data, data, sum.Type())
+			}
+
+			switch value.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				sum.SetInt(sum.Int() + value.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				sum.SetUint(sum.Uint() + value.Uint())
+			case reflect.Float32, reflect.Float64:
+				sum.SetFloat(sum.Float() + value.Float())
+			default:
+				return fmt.Errorf("Cannot sum %v of non numeric type %T", data, data)
+			}
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if !sum.IsValid() {
+				emitter.Emit(0)
+				return
+			}
+			emitter.Emit(sum.Interface())
+		},
+	}
+}
+
+// Min emits the smallest item according to less once upstream completes,
+// the first one seen among equals. Empty streams emit nothing.
+func Min(less stream.SortByFn) stream.Transformer {
+	var min stream.T
+	seen := false
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if !seen || less(data, min) {
+				min = data
+				seen = true
+			}
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if seen {
+				emitter.Emit(min)
+			}
+		},
+	}
+}
+
+// Max emits the largest item according to less once upstream completes,
+// the first one seen among equals. Empty streams emit nothing.
+func Max(less stream.SortByFn) stream.Transformer {
+	return Min(func(a, b stream.T) bool { return less(b, a) })
+}
@@ -0,0 +1,102 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAggregations(t *testing.T) {
+	less := func(a, b stream.T) bool { return a.(int) < b.(int) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of numbers", func() {
+			in, out := stream.New(4)
+			out <- 3
+			out <- 1
+			out <- 4
+			out <- 2
+			close(out)
+
+			Convey("When I count the items", func() {
+				transformer := transformers.Count()
+				transformer.Attach(context)
+
+				Convey("Then the number of items is emitted", func() {
+					So(transformer.Transform(in).ReadAll(), ShouldResemble, []stream.T{4})
+				})
+			})
+
+			Convey("When I sum the items", func() {
+				transformer := transformers.Sum()
+				transformer.Attach(context)
+
+				Convey("Then their sum is emitted", func() {
+					So(transformer.Transform(in).ReadAll(), ShouldResemble, []stream.T{10})
+				})
+			})
+
+			Convey("When I look for the smallest item", func() {
+				transformer := transformers.Min(less)
+				transformer.Attach(context)
+
+				Convey("Then it is emitted", func() {
+					So(transformer.Transform(in).ReadAll(), ShouldResemble, []stream.T{1})
+				})
+			})
+
+			Convey("When I look for the largest item", func() {
+				transformer := transformers.Max(less)
+				transformer.Attach(context)
+
+				Convey("Then it is emitted", func() {
+					So(transformer.Transform(in).ReadAll(), ShouldResemble, []stream.T{4})
+				})
+			})
+		})
+
+		Convey("And a stream of mixed types", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 1.5
+			close(out)
+
+			Convey("When I sum the items", func() {
+				transformer := transformers.Sum()
+				transformer.Attach(context)
+
+				Convey("Then the context is closed with a descriptive error", func() {
+					So(transformer.Transform(in).ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldEqual, "Cannot sum 1.5 of type float64 to a sum of type int")
+				})
+			})
+		})
+
+		Convey("And an empty stream", func() {
+			in, out := stream.New(0)
+			close(out)
+
+			Convey("When I count the items", func() {
+				transformer := transformers.Count()
+				transformer.Attach(context)
+
+				Convey("Then zero is emitted", func() {
+					So(transformer.Transform(in).ReadAll(), ShouldResemble, []stream.T{0})
+				})
+			})
+
+			Convey("When I look for the smallest item", func() {
+				transformer := transformers.Min(less)
+				transformer.Attach(context)
+
+				Convey("Then nothing is emitted", func() {
+					So(transformer.Transform(in).ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
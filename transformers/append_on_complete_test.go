@@ -0,0 +1,47 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAppendOnComplete(t *testing.T) {
+	eof := func() stream.T { return "EOF" }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+
+			Convey("When I apply the transformer to the stream", func() {
+				close(out)
+				transformer := transformers.AppendOnComplete(eof)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the sentinel is emitted after all items", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, "EOF"})
+				})
+			})
+
+			Convey("When the upstream fails before closing", func() {
+				context.Close(errors.New("upstream failure"))
+				close(out)
+				transformer := transformers.AppendOnComplete(eof)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no sentinel is sent to the next stage", func() {
+					So(next.ReadAll(), ShouldNotContain, "EOF")
+				})
+			})
+		})
+	})
+}
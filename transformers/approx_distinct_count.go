@@ -0,0 +1,94 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type approxDistinctCount struct {
+	context stream.Context
+	window  time.Duration
+	key     func(stream.T) string
+	onCount func(count uint64)
+}
+
+// ApproxDistinctCount emits, instead of the items themselves, the
+// approximate number of distinct keys seen within each window, estimated
+// with a HyperLogLog sketch so memory stays bounded for any cardinality.
+// The count of the last, possibly partial, window is emitted on completion.
+// A window not greater than zero closes the context with ErrInvalidWindow.
+func ApproxDistinctCount(window time.Duration, key func(stream.T) string) *approxDistinctCount {
+	return &approxDistinctCount{
+		window: window,
+		key:    key,
+	}
+}
+
+// OnCount forwards items unchanged reporting each window's count to fn instead
+func (counter *approxDistinctCount) OnCount(fn func(count uint64)) stream.Transformer {
+	counter.onCount = fn
+	return counter
+}
+
+func (counter *approxDistinctCount) Attach(context stream.Context) {
+	counter.context = context
+}
+
+func (counter *approxDistinctCount) Transform(in stream.Readable) stream.Readable {
+	if counter.window <= 0 {
+		invalid := &empty{err: ErrInvalidWindow}
+		invalid.Attach(counter.context)
+		return invalid.Transform(in)
+	}
+
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(counter.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer counter.context.Recover()
+
+		ticker := time.NewTicker(counter.window)
+		defer ticker.Stop()
+
+		hll := newHyperLogLog()
+		seen := false
+		report := func() {
+			if counter.onCount != nil {
+				counter.onCount(hll.estimate())
+			} else {
+				emitter.Emit(hll.estimate())
+			}
+			hll.reset()
+			seen = false
+		}
+
+		for {
+			select {
+			case <-counter.context.Failure():
+				return
+			case <-counter.context.Done():
+				return
+			case <-time.After(counter.context.Deadline()):
+				panic(stream.Timeout)
+			case <-ticker.C:
+				report()
+			case data, more := <-in:
+				if !more {
+					if seen {
+						report()
+					}
+					return
+				}
+
+				hll.add(counter.key(data))
+				seen = true
+				if counter.onCount != nil {
+					emitter.Emit(data)
+				}
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,91 @@
+package transformers_test
+
+import (
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestApproxDistinctCount(t *testing.T) {
+	key := func(data stream.T) string { return fmt.Sprint(data.(int) % 7) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data with 7 distinct keys", func() {
+			in, out := stream.New(100)
+			for i := 0; i < 100; i++ {
+				out <- i
+			}
+
+			Convey("When I apply the transformer to the stream", func() {
+				close(out)
+				transformer := transformers.ApproxDistinctCount(time.Hour, key)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the distinct count of the window is emitted on completion", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{uint64(7)})
+				})
+			})
+
+			Convey("When I apply the transformer reporting counts to a callback", func() {
+				close(out)
+				counts := []uint64{}
+				transformer := transformers.ApproxDistinctCount(time.Hour, key).OnCount(func(count uint64) {
+					counts = append(counts, count)
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded unchanged", func() {
+					So(len(next.ReadAll()), ShouldEqual, 100)
+					So(counts, ShouldResemble, []uint64{7})
+				})
+			})
+
+			Convey("When the window elapses before the stream completes", func() {
+				transformer := transformers.ApproxDistinctCount(50*time.Millisecond, key)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the count is emitted and the estimator reset", func() {
+					So(<-next, ShouldEqual, uint64(7))
+					close(out)
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When I apply the transformer with an invalid window", func() {
+				close(out)
+				transformer := transformers.ApproxDistinctCount(0, key)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidWindow)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				close(out)
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.ApproxDistinctCount(time.Hour, key)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no count is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAround(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		hooked := []stream.T{}
+		onFirst := func(data stream.T) { hooked = append(hooked, "first", data) }
+		onLast := func(data stream.T) { hooked = append(hooked, "last", data) }
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+
+			Convey("When I apply the transformer to the stream", func() {
+				close(out)
+				transformer := transformers.Around(onFirst, onLast)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded and the hooks called with the first and last ones", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+					So(hooked, ShouldResemble, []stream.T{"first", 1, "last", 3})
+				})
+			})
+
+			Convey("When the upstream fails before closing", func() {
+				context.Close(errors.New("upstream failure"))
+				close(out)
+				transformer := transformers.Around(onFirst, onLast)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the last item hook is not called", func() {
+					next.ReadAll()
+					So(hooked, ShouldNotContain, "last")
+				})
+			})
+		})
+
+		Convey("And an empty stream", func() {
+			in, out := stream.New(0)
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Around(onFirst, onLast)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no hook is called", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(hooked, ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestBatchBytes(t *testing.T) {
+	size := func(data stream.T) int { return len(data.(string)) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- "ab"
+			out <- "cd"
+			out <- "efghij"
+			out <- "k"
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.BatchBytes(4, size)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are batched by their accumulated size", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						[]stream.T{"ab", "cd"},
+						[]stream.T{"efghij"},
+						[]stream.T{"k"},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.BatchBytes(4, size)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -61,9 +61,30 @@ func TestBatcher(t *testing.T) {
 				})
 			})
 
+			Convey("When I apply the batch transformer with an invalid size", func() {
+				transformer := transformers.Batch(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidBatchSize)
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
+				Convey("And I apply the batch transformer to the stream", func() {
+					transformer := transformers.Batch(2)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then the partial batch is discarded", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+
 				Convey("And I apply the transformer to the stream", func() {
 					transformer := transformers.Flatten()
 					transformer.Attach(context)
@@ -0,0 +1,53 @@
+package transformers
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes int
+}
+
+// newBloomFilter sizes the filter so that adding expectedN keys keeps
+// the probability of false positives around falsePositiveRate
+func newBloomFilter(expectedN int, falsePositiveRate float64) *bloomFilter {
+	if expectedN <= 0 {
+		expectedN = 1
+	}
+
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	size := math.Ceil(-float64(expectedN) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := int(math.Max(1, math.Round(size/float64(expectedN)*math.Ln2)))
+
+	return &bloomFilter{
+		bits:   make([]uint64, (uint64(size)+63)/64),
+		size:   uint64(size),
+		hashes: hashes,
+	}
+}
+
+// add sets the bits for key, returning whether all of them were
+// already set, i.e. whether key was probably added before
+func (filter *bloomFilter) add(key string) bool {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h1 := h.Sum64()
+	h2 := h1>>33 | h1<<31 | 1
+
+	present := true
+	for i := 0; i < filter.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % filter.size
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if filter.bits[word]&mask == 0 {
+			present = false
+			filter.bits[word] |= mask
+		}
+	}
+	return present
+}
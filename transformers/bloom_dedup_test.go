@@ -0,0 +1,71 @@
+package transformers_test
+
+import (
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestBloomDedup(t *testing.T) {
+	key := func(data stream.T) string { return fmt.Sprint(data) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data with duplicates", func() {
+			in, out := stream.New(6)
+			out <- 1
+			out <- 2
+			out <- 1
+			out <- 3
+			out <- 2
+			out <- 1
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.BloomDedup(100, 0.001, key)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then duplicates are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.BloomDedup(100, 0.001, key)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And a large stream of unique data", func() {
+			in, out := stream.New(10000)
+			for i := 0; i < 10000; i++ {
+				out <- i
+			}
+			close(out)
+
+			Convey("When I apply the transformer sized for it", func() {
+				transformer := transformers.BloomDedup(10000, 0.01, key)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then roughly the false positive rate of items is dropped", func() {
+					So(len(next.ReadAll()), ShouldBeGreaterThan, 9800)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package transformers
+
+import (
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+var ErrInvalidInterval = errors.New("Interval must be greater than zero")
+
+type bufferTime struct {
+	context  stream.Context
+	interval time.Duration
+}
+
+// BufferTime collects items emitting them as a []stream.T every interval,
+// skipping intervals without items, and flushes the remaining ones once
+// upstream completes. An interval not greater than zero closes the context
+// with ErrInvalidInterval.
+func BufferTime(interval time.Duration) stream.Transformer {
+	if interval <= 0 {
+		return &empty{err: ErrInvalidInterval}
+	}
+
+	return &bufferTime{
+		interval: interval,
+	}
+}
+
+func (transformer *bufferTime) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *bufferTime) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer transformer.context.Recover()
+
+		ticker := time.NewTicker(transformer.interval)
+		defer ticker.Stop()
+
+		items := []stream.T{}
+		flush := func() {
+			if len(items) > 0 {
+				emitter.Emit(items)
+				items = []stream.T{}
+			}
+		}
+
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case <-ticker.C:
+				flush()
+			case data, more := <-in:
+				if !more {
+					flush()
+					return
+				}
+				items = append(items, data)
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,76 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestBufferTime(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+
+			Convey("When I apply the transformer with a long interval", func() {
+				out <- 3
+				close(out)
+				transformer := transformers.BufferTime(time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then buffered items are flushed once the stream completes", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{1, 2, 3}})
+				})
+			})
+
+			Convey("When intervals elapse while items arrive", func() {
+				transformer := transformers.BufferTime(20 * time.Millisecond)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				So(<-next, ShouldResemble, []stream.T{1, 2})
+				time.Sleep(60 * time.Millisecond)
+				out <- 3
+				close(out)
+
+				Convey("Then empty intervals are not emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{3}})
+				})
+			})
+
+			Convey("When I apply the transformer with an invalid interval", func() {
+				close(out)
+				transformer := transformers.BufferTime(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidInterval)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				close(out)
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.BufferTime(time.Hour)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type span struct {
+	start, end int64
+}
+
+func TestCoalesceRanges(t *testing.T) {
+	start := func(data stream.T) int64 { return data.(span).start }
+	end := func(data stream.T) int64 { return data.(span).end }
+	build := func(start, end int64) stream.T { return span{start, end} }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a sorted stream of ranges", func() {
+			in, out := stream.New(4)
+			out <- span{1, 3}
+			out <- span{2, 5}
+			out <- span{6, 6}
+			out <- span{8, 9}
+
+			Convey("When I apply the transformer to the stream", func() {
+				close(out)
+				transformer := transformers.CoalesceRanges(start, end, build)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then overlapping and adjacent ranges are merged", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{span{1, 6}, span{8, 9}})
+				})
+			})
+
+			Convey("When the upstream fails before closing", func() {
+				context.Close(errors.New("upstream failure"))
+				close(out)
+				transformer := transformers.CoalesceRanges(start, end, build)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the pending range is not sent to the next stage", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,132 @@
+package transformers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"reflect"
+	"strconv"
+)
+
+var ErrNoSuchRecord = errors.New("Element is not a map[string]stream.T")
+
+type coerce struct {
+	context    stream.Context
+	schema     map[string]reflect.Kind
+	deadLetter stream.Writable
+}
+
+// Coerce converts the fields of map[string]stream.T items to the kinds
+// declared by schema, parsing strings and converting between numeric
+// kinds. Supported kinds are String, Bool, Int, Int64 and Float64. Items
+// failing coercion close the context unless a dead letter stream is
+// given, see DeadLetterTo.
+func Coerce(schema map[string]reflect.Kind) *coerce {
+	return &coerce{
+		schema: schema,
+	}
+}
+
+// DeadLetterTo sends a DeadLetter to deadLetter for every item failing
+// coercion instead of closing the context. The dead letter stream is not
+// closed by the transformer and must be drained for the stream to make
+// progress.
+func (transformer *coerce) DeadLetterTo(deadLetter stream.Writable) *coerce {
+	transformer.deadLetter = deadLetter
+	return transformer
+}
+
+func (transformer *coerce) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *coerce) Transform(in stream.Readable) stream.Readable {
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			coerced, err := transformer.coerce(data)
+			if err == nil {
+				emitter.Emit(coerced)
+				return nil
+			}
+
+			if transformer.deadLetter == nil {
+				return err
+			}
+
+			select {
+			case <-transformer.context.Failure():
+			case <-transformer.context.Done():
+			case transformer.deadLetter <- DeadLetter{Item: data, Err: err}:
+			}
+			return nil
+		},
+	}
+
+	observer.Attach(transformer.context)
+	return observer.Transform(in)
+}
+
+func (transformer *coerce) coerce(data stream.T) (map[string]stream.T, error) {
+	record, ok := data.(map[string]stream.T)
+	if !ok {
+		return nil, ErrNoSuchRecord
+	}
+
+	coerced := make(map[string]stream.T, len(record))
+	for field, value := range record {
+		kind, declared := transformer.schema[field]
+		if !declared {
+			coerced[field] = value
+			continue
+		}
+
+		converted, err := coerceTo(kind, value)
+		if err != nil {
+			return nil, fmt.Errorf("Could not coerce field %q: %v", field, err)
+		}
+		coerced[field] = converted
+	}
+	return coerced, nil
+}
+
+func coerceTo(kind reflect.Kind, value stream.T) (stream.T, error) {
+	if s, ok := value.(string); ok {
+		switch kind {
+		case reflect.String:
+			return s, nil
+		case reflect.Bool:
+			return strconv.ParseBool(s)
+		case reflect.Int:
+			return strconv.Atoi(s)
+		case reflect.Int64:
+			return strconv.ParseInt(s, 10, 64)
+		case reflect.Float64:
+			return strconv.ParseFloat(s, 64)
+		}
+		return nil, fmt.Errorf("unsupported kind %v", kind)
+	}
+
+	if kind == reflect.String {
+		return fmt.Sprint(value), nil
+	}
+
+	v := reflect.ValueOf(value)
+	var target reflect.Type
+	switch kind {
+	case reflect.Bool:
+		target = reflect.TypeOf(false)
+	case reflect.Int:
+		target = reflect.TypeOf(0)
+	case reflect.Int64:
+		target = reflect.TypeOf(int64(0))
+	case reflect.Float64:
+		target = reflect.TypeOf(float64(0))
+	default:
+		return nil, fmt.Errorf("unsupported kind %v", kind)
+	}
+
+	if !v.IsValid() || v.Kind() != target.Kind() && (v.Kind() == reflect.Bool || target.Kind() == reflect.Bool || !v.Type().ConvertibleTo(target)) {
+		return nil, fmt.Errorf("%v is not convertible to %v", value, kind)
+	}
+	return v.Convert(target).Interface(), nil
+}
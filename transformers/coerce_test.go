@@ -0,0 +1,81 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"reflect"
+	"testing"
+)
+
+func TestCoerce(t *testing.T) {
+	schema := map[string]reflect.Kind{
+		"age":    reflect.Int,
+		"score":  reflect.Float64,
+		"active": reflect.Bool,
+		"id":     reflect.String,
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of coercible records", func() {
+			in, out := stream.New(2)
+			out <- map[string]stream.T{"age": "42", "score": 3, "active": "true", "id": 7, "name": "bob"}
+			out <- map[string]stream.T{"age": int64(30)}
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Coerce(schema)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then fields are converted to the declared kinds", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						map[string]stream.T{"age": 42, "score": float64(3), "active": true, "id": "7", "name": "bob"},
+						map[string]stream.T{"age": 30},
+					})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("And a stream with uncoercible items", func() {
+			in, out := stream.New(3)
+			out <- map[string]stream.T{"age": "x"}
+			out <- 3
+			out <- map[string]stream.T{"age": "1"}
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Coerce(schema)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the coercion error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldNotBeNil)
+				})
+			})
+
+			Convey("When I apply the transformer with a dead letter stream", func() {
+				deadLetters, writable := stream.New(2)
+				transformer := transformers.Coerce(schema).DeadLetterTo(writable)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then coerced items are emitted and failed ones dead lettered", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{map[string]stream.T{"age": 1}})
+					close(writable)
+
+					failed := deadLetters.ReadAll()
+					So(failed, ShouldHaveLength, 2)
+					So(failed[0].(transformers.DeadLetter).Item, ShouldResemble, map[string]stream.T{"age": "x"})
+					So(failed[1].(transformers.DeadLetter).Err, ShouldEqual, transformers.ErrNoSuchRecord)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+		})
+	})
+}
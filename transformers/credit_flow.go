@@ -0,0 +1,49 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+type creditFlow struct {
+	context stream.Context
+	credits <-chan int
+}
+
+// CreditFlow emits one item per credit received from credits, holding
+// items back while no credits are left. Closing credits tells upstream
+// stages to shutdown without errors once the remaining credits are used.
+func CreditFlow(credits <-chan int) stream.Transformer {
+	return &creditFlow{
+		credits: credits,
+	}
+}
+
+func (transformer *creditFlow) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *creditFlow) Transform(in stream.Readable) stream.Readable {
+	available := 0
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			for available <= 0 {
+				select {
+				case <-transformer.context.Failure():
+					return nil
+				case <-transformer.context.Done():
+					return nil
+				case credits, more := <-transformer.credits:
+					if !more {
+						return stream.Done
+					}
+					available += credits
+				}
+			}
+
+			emitter.Emit(data)
+			available--
+			return nil
+		},
+	}
+
+	observer.Attach(transformer.context)
+	return observer.Transform(in)
+}
@@ -0,0 +1,74 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestCreditFlow(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the transformer with fewer credits than items", func() {
+				credits := make(chan int, 1)
+				credits <- 2
+				transformer := transformers.CreditFlow(credits)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are held back until more credits arrive", func() {
+					So(<-next, ShouldEqual, 1)
+					So(<-next, ShouldEqual, 2)
+
+					select {
+					case <-next:
+						t.Error("item emitted without credits")
+					case <-time.After(50 * time.Millisecond):
+					}
+
+					credits <- 1
+					So(next.ReadAll(), ShouldResemble, []stream.T{3})
+				})
+			})
+
+			Convey("When the credits channel is closed", func() {
+				credits := make(chan int, 1)
+				credits <- 1
+				close(credits)
+				transformer := transformers.CreditFlow(credits)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the stream stops once the credits are used", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.CreditFlow(make(chan int))
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
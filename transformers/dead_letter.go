@@ -0,0 +1,10 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+// DeadLetter is sent to the dead letter stream of transformers supporting
+// one, e.g. StreamingLookupJoin, for items that failed with Err
+type DeadLetter struct {
+	Item stream.T
+	Err  error
+}
@@ -0,0 +1,71 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type debounce struct {
+	context stream.Context
+	quiet   time.Duration
+}
+
+// Debounce emits the most recent item once quiet has elapsed without a
+// new one arriving, flushing the pending item once upstream completes
+func Debounce(quiet time.Duration) stream.Transformer {
+	return &debounce{
+		quiet: quiet,
+	}
+}
+
+func (transformer *debounce) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *debounce) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer transformer.context.Recover()
+
+		timer := time.NewTimer(transformer.quiet)
+		timer.Stop()
+		defer timer.Stop()
+
+		var pending stream.T
+		var elapsed <-chan time.Time
+
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case <-elapsed:
+				elapsed = nil
+				emitter.Emit(pending)
+				pending = nil
+			case data, more := <-in:
+				if !more {
+					if elapsed != nil {
+						emitter.Emit(pending)
+					}
+					return
+				}
+
+				if elapsed != nil && !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(transformer.quiet)
+				elapsed = timer.C
+				pending = data
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,139 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"math"
+	"sort"
+	"time"
+)
+
+// negligibleScore is the score under which keys are forgotten
+const negligibleScore = 1e-3
+
+type DecayedScore struct {
+	Key   stream.T
+	Score float64
+}
+
+type decayedEntry struct {
+	score float64
+	last  time.Time
+}
+
+type decayingCount struct {
+	context  stream.Context
+	halfLife time.Duration
+	key      stream.MapFn
+	interval time.Duration
+	top      int
+}
+
+// DecayingCount scores keys by the number of items seen, each item's
+// weight halving every halfLife, emitting the top 10 scores in descending
+// order every halfLife and once the stream completes, see Every and Top.
+// Scores are only decayed when their key is seen or emitted. A half life or
+// interval not greater than zero closes the context with ErrInvalidInterval.
+func DecayingCount(halfLife time.Duration, key stream.MapFn) *decayingCount {
+	return &decayingCount{
+		halfLife: halfLife,
+		key:      key,
+		interval: halfLife,
+		top:      10,
+	}
+}
+
+// Every emits the top scores at the given interval
+func (counter *decayingCount) Every(interval time.Duration) *decayingCount {
+	counter.interval = interval
+	return counter
+}
+
+// Top emits only the k highest scores, none if k is not greater than zero
+func (counter *decayingCount) Top(k int) *decayingCount {
+	if k < 0 {
+		k = 0
+	}
+	counter.top = k
+	return counter
+}
+
+func (counter *decayingCount) Attach(context stream.Context) {
+	counter.context = context
+}
+
+func (counter *decayingCount) decay(entry *decayedEntry, now time.Time) {
+	elapsed := now.Sub(entry.last).Seconds() / counter.halfLife.Seconds()
+	entry.score *= math.Pow(2, -elapsed)
+	entry.last = now
+}
+
+func (counter *decayingCount) Transform(in stream.Readable) stream.Readable {
+	if counter.halfLife <= 0 || counter.interval <= 0 {
+		invalid := &empty{err: ErrInvalidInterval}
+		invalid.Attach(counter.context)
+		return invalid.Transform(in)
+	}
+
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(counter.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer counter.context.Recover()
+
+		ticker := time.NewTicker(counter.interval)
+		defer ticker.Stop()
+
+		entries := make(map[stream.T]*decayedEntry)
+		emitTop := func() {
+			now := time.Now()
+			scores := []DecayedScore{}
+			for key, entry := range entries {
+				counter.decay(entry, now)
+				if entry.score < negligibleScore {
+					delete(entries, key)
+					continue
+				}
+				scores = append(scores, DecayedScore{Key: key, Score: entry.score})
+			}
+
+			sort.Slice(scores, func(i, j int) bool { return scores[i].Score > scores[j].Score })
+			if len(scores) > counter.top {
+				scores = scores[:counter.top]
+			}
+			emitter.Emit(scores)
+		}
+
+		for {
+			select {
+			case <-counter.context.Failure():
+				return
+			case <-counter.context.Done():
+				return
+			case <-time.After(counter.context.Deadline()):
+				panic(stream.Timeout)
+			case <-ticker.C:
+				emitTop()
+			case data, more := <-in:
+				if !more {
+					if len(entries) > 0 {
+						emitTop()
+					}
+					return
+				}
+
+				now := time.Now()
+				key := counter.key(data)
+				entry, exists := entries[key]
+				if !exists {
+					entry = &decayedEntry{last: now}
+					entries[key] = entry
+				}
+				counter.decay(entry, now)
+				entry.score++
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,113 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestDecayingCount(t *testing.T) {
+	key := func(data stream.T) stream.T { return data }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(6)
+			out <- "a"
+			out <- "b"
+			out <- "a"
+			out <- "c"
+			out <- "a"
+			out <- "b"
+
+			Convey("When I apply the transformer with a long half life", func() {
+				close(out)
+				transformer := transformers.DecayingCount(time.Hour, key).Top(2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the top scores are emitted once the stream completes", func() {
+					items := next.ReadAll()
+					So(len(items), ShouldEqual, 1)
+
+					scores := items[0].([]transformers.DecayedScore)
+					So(len(scores), ShouldEqual, 2)
+					So(scores[0].Key, ShouldEqual, "a")
+					So(scores[0].Score, ShouldAlmostEqual, 3, 0.01)
+					So(scores[1].Key, ShouldEqual, "b")
+					So(scores[1].Score, ShouldAlmostEqual, 2, 0.01)
+				})
+			})
+
+			Convey("When older items decay past newer ones", func() {
+				transformer := transformers.DecayingCount(20*time.Millisecond, key).Every(time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				time.Sleep(100 * time.Millisecond)
+				out <- "d"
+				out <- "d"
+				close(out)
+
+				Convey("Then the most recent key is ranked first", func() {
+					scores := next.ReadAll()[0].([]transformers.DecayedScore)
+					So(scores[0].Key, ShouldEqual, "d")
+				})
+			})
+
+			Convey("When I apply the transformer with a negative top", func() {
+				close(out)
+				transformer := transformers.DecayingCount(time.Hour, key).Top(-1)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no score is emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]transformers.DecayedScore{}})
+				})
+			})
+
+			Convey("When I apply the transformer with an invalid half life", func() {
+				close(out)
+				transformer := transformers.DecayingCount(0, key)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidInterval)
+				})
+			})
+
+			Convey("When I apply the transformer with an invalid interval", func() {
+				close(out)
+				transformer := transformers.DecayingCount(time.Hour, key).Every(-time.Second)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidInterval)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				close(out)
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.DecayingCount(time.Hour, key)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no score is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
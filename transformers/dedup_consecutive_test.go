@@ -0,0 +1,65 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestDedupConsecutive(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream with adjacent duplicates", func() {
+			in, out := stream.New(5)
+			out <- "a"
+			out <- "a"
+			out <- "b"
+			out <- "B"
+			out <- "a"
+			close(out)
+
+			Convey("When I apply the transformer", func() {
+				transformer := transformers.DedupConsecutive()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only adjacent duplicates are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "b", "B", "a"})
+				})
+			})
+
+			Convey("When I apply the transformer by key", func() {
+				transformer := transformers.DedupConsecutiveBy(func(data stream.T) stream.T {
+					return strings.ToLower(data.(string))
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items with the same key as the previous one are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "b", "a"})
+				})
+			})
+		})
+
+		Convey("And a stream with non comparable items", func() {
+			in, out := stream.New(1)
+			out <- []int{1}
+			close(out)
+
+			Convey("When I compact the stream", func() {
+				transformer := transformers.Compact()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with a descriptive error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrNoSuchComparable)
+				})
+			})
+		})
+	})
+}
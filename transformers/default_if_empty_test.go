@@ -0,0 +1,59 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestDefaultIfEmpty(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.DefaultIfEmpty(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded unchanged", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2})
+				})
+			})
+		})
+
+		Convey("And an empty stream", func() {
+			in, out := stream.New(0)
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.DefaultIfEmpty(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the default value is emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{0})
+				})
+			})
+
+			Convey("When the upstream fails", func() {
+				context.Close(errors.New("upstream failure"))
+				transformer := transformers.DefaultIfEmpty(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the default value is not emitted", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type timed struct {
+	at   time.Time
+	data stream.T
+}
+
+type delay struct {
+	context stream.Context
+	delay   time.Duration
+}
+
+// Delay forwards every item d after it arrives, preserving both order and
+// the spacing between items, as long as no more than the stream capacity
+// of them are being delayed at once
+func Delay(d time.Duration) stream.Transformer {
+	return &delay{
+		delay: d,
+	}
+}
+
+func (transformer *delay) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *delay) Transform(in stream.Readable) stream.Readable {
+	arrivals := make(chan timed, in.Capacity())
+	go func() {
+		defer close(arrivals)
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				select {
+				case <-transformer.context.Failure():
+					return
+				case <-transformer.context.Done():
+					return
+				case arrivals <- timed{time.Now(), data}:
+				}
+			}
+		}
+	}()
+
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer transformer.context.Recover()
+
+		for item := range arrivals {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(item.at.Add(transformer.delay).Sub(time.Now())):
+				emitter.Emit(item.data)
+			}
+		}
+	}()
+
+	return readable
+}
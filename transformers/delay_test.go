@@ -0,0 +1,48 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				start := time.Now()
+				transformer := transformers.Delay(20 * time.Millisecond)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded in order after the delay without piling it up", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+					So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 20*time.Millisecond)
+					So(time.Since(start), ShouldBeLessThan, 60*time.Millisecond)
+				})
+			})
+
+			Convey("When I close the context while items are delayed", func() {
+				transformer := transformers.Delay(time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				context.Close(stream.Done)
+
+				Convey("Then the stream is closed right away", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"reflect"
+)
+
+type DiffOp int
+
+const (
+	Added DiffOp = iota
+	Changed
+	Unchanged
+	Removed
+)
+
+// Diff tells how an item compares to the prior snapshot. Removed diffs
+// carry the prior value as Item since there is no such item in the stream.
+type Diff struct {
+	Op   DiffOp
+	Key  stream.T
+	Item stream.T
+}
+
+type diffAgainst struct {
+	context       stream.Context
+	prior         map[stream.T]stream.T
+	key           stream.MapFn
+	value         stream.MapFn
+	dropUnchanged bool
+	emitRemoved   bool
+}
+
+// DiffAgainst emits a Diff per item comparing its value to the one found
+// under its key in the prior snapshot, values being deeply compared
+func DiffAgainst(prior map[stream.T]stream.T, key, value stream.MapFn) *diffAgainst {
+	return &diffAgainst{
+		prior: prior,
+		key:   key,
+		value: value,
+	}
+}
+
+// DropUnchanged skips items whose value did not change since the snapshot
+func (diff *diffAgainst) DropUnchanged() *diffAgainst {
+	diff.dropUnchanged = true
+	return diff
+}
+
+// EmitRemoved emits, once the stream completes, a Removed diff for every
+// snapshot key not seen in the stream, in no particular order
+func (diff *diffAgainst) EmitRemoved() *diffAgainst {
+	diff.emitRemoved = true
+	return diff
+}
+
+func (diff *diffAgainst) Attach(context stream.Context) {
+	diff.context = context
+}
+
+func (diff *diffAgainst) Transform(in stream.Readable) stream.Readable {
+	seen := make(map[stream.T]bool)
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			key := diff.key(data)
+			seen[key] = true
+
+			prior, exists := diff.prior[key]
+			switch {
+			case !exists:
+				emitter.Emit(Diff{Op: Added, Key: key, Item: data})
+			case !reflect.DeepEqual(prior, diff.value(data)):
+				emitter.Emit(Diff{Op: Changed, Key: key, Item: data})
+			case !diff.dropUnchanged:
+				emitter.Emit(Diff{Op: Unchanged, Key: key, Item: data})
+			}
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if !diff.emitRemoved {
+				return
+			}
+
+			for key, prior := range diff.prior {
+				if !seen[key] {
+					emitter.Emit(Diff{Op: Removed, Key: key, Item: prior})
+				}
+			}
+		},
+	}
+
+	observer.Attach(diff.context)
+	return observer.Transform(in)
+}
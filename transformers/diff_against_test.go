@@ -0,0 +1,74 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type record struct {
+	id    string
+	value int
+}
+
+func TestDiffAgainst(t *testing.T) {
+	key := func(data stream.T) stream.T { return data.(record).id }
+	value := func(data stream.T) stream.T { return data.(record).value }
+	prior := map[stream.T]stream.T{"a": 1, "b": 2, "c": 3}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of records", func() {
+			in, out := stream.New(3)
+			out <- record{"a", 1}
+			out <- record{"b", 20}
+			out <- record{"d", 4}
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.DiffAgainst(prior, key, value)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then each record is diffed against the snapshot", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.Diff{Op: transformers.Unchanged, Key: "a", Item: record{"a", 1}},
+						transformers.Diff{Op: transformers.Changed, Key: "b", Item: record{"b", 20}},
+						transformers.Diff{Op: transformers.Added, Key: "d", Item: record{"d", 4}},
+					})
+				})
+			})
+
+			Convey("When I drop unchanged records and emit removed ones", func() {
+				transformer := transformers.DiffAgainst(prior, key, value).DropUnchanged().EmitRemoved()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only the delta is emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.Diff{Op: transformers.Changed, Key: "b", Item: record{"b", 20}},
+						transformers.Diff{Op: transformers.Added, Key: "d", Item: record{"d", 4}},
+						transformers.Diff{Op: transformers.Removed, Key: "c", Item: 3},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.DiffAgainst(prior, key, value).EmitRemoved()
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no diff is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
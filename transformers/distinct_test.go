@@ -0,0 +1,66 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream with duplicated items", func() {
+			in, out := stream.New(5)
+			out <- "a"
+			out <- "b"
+			out <- "a"
+			out <- "B"
+			out <- "c"
+			close(out)
+
+			Convey("When I apply the distinct transformer", func() {
+				transformer := transformers.Distinct()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only the first occurrence of each item is forwarded", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "b", "B", "c"})
+				})
+			})
+
+			Convey("When I apply the distinct by transformer", func() {
+				transformer := transformers.DistinctBy(func(data stream.T) stream.T {
+					return strings.ToLower(data.(string))
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only the first item of each key is forwarded", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a", "b", "c"})
+				})
+			})
+		})
+
+		Convey("And a stream with non comparable items", func() {
+			in, out := stream.New(2)
+			out <- []int{1}
+			out <- []int{1}
+			close(out)
+
+			Convey("When I apply the distinct transformer", func() {
+				transformer := transformers.Distinct()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with a descriptive error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrNoSuchComparable)
+				})
+			})
+		})
+	})
+}
@@ -39,6 +39,31 @@ func TestEach(t *testing.T) {
 				})
 			})
 
+			Convey("When I tap into the stream", func() {
+				var items []stream.T
+				transformer := transformers.Tap(collect(&items))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded unchanged", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2})
+					So(items, ShouldResemble, []stream.T{1, 2})
+				})
+			})
+
+			Convey("When the tapped function panics", func() {
+				transformer := transformers.Tap(func(data stream.T) {
+					panic("tap failure")
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the recovered error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldEqual, "Recovered from tap failure")
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
@@ -0,0 +1,51 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestElementAt(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- "a"
+			out <- "b"
+			out <- "c"
+
+			Convey("When I select an item within the stream", func() {
+				transformer := transformers.ElementAt(1)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only that item is forwarded and upstream is stopped right away", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"b"})
+					So(context.Err(), ShouldBeNil)
+
+					select {
+					case <-context.Done():
+					default:
+						t.Error("context is not done")
+					}
+				})
+			})
+
+			Convey("When I select an item past the end of the stream", func() {
+				close(out)
+				transformer := transformers.ElementAt(3)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrIndexOutOfRange)
+				})
+			})
+		})
+	})
+}
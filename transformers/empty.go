@@ -0,0 +1,21 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+// empty closes its stream right away telling upstream stages to shutdown,
+// failing the context with err if one is given
+type empty struct {
+	context stream.Context
+	err     error
+}
+
+func (transformer *empty) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *empty) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(0)
+	close(writable)
+	transformer.context.Close(transformer.err)
+	return readable
+}
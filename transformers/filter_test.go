@@ -30,6 +30,16 @@ func TestFilter(t *testing.T) {
 				})
 			})
 
+			Convey("When I apply the negated transformer to the stream", func() {
+				transformer := transformers.FilterNot(evens)
+				transformer.Attach(context)
+				transformed := transformer.Transform(in)
+
+				Convey("Then only items not matching the predicate are returned", func() {
+					So(transformed.ReadAll(), ShouldResemble, []stream.T{1})
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
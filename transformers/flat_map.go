@@ -0,0 +1,49 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+type flatMap struct {
+	context stream.Context
+	fn      func(stream.T) stream.Producer
+}
+
+// FlatMap expands every item into the stream produced by the producer fn
+// returns, fully draining it before moving on to the next item. Producers
+// are attached to the transformer's context, so their failures stop the
+// expansion altogether.
+func FlatMap(fn func(stream.T) stream.Producer) stream.Transformer {
+	return &flatMap{
+		fn: fn,
+	}
+}
+
+func (transformer *flatMap) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *flatMap) Transform(in stream.Readable) stream.Readable {
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			producer := transformer.fn(data)
+			producer.Attach(transformer.context)
+			inner := producer.Produce()
+
+			for {
+				select {
+				case <-transformer.context.Failure():
+					return nil
+				case <-transformer.context.Done():
+					return nil
+				case item, more := <-inner:
+					if !more {
+						return nil
+					}
+					emitter.Emit(item)
+				}
+			}
+		},
+	}
+
+	observer.Attach(transformer.context)
+	return observer.Transform(in)
+}
@@ -0,0 +1,105 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type flatMapParallel struct {
+	context       stream.Context
+	maxConcurrent int
+	fn            func(stream.T) stream.Producer
+}
+
+// FlatMapParallel expands every item into the stream produced by the
+// producer fn returns, like FlatMap, draining up to maxConcurrent of them
+// at once and merging their items as they become available, so order is
+// not guaranteed. Producers are attached to the transformer's context, so
+// the first failure stops the expansion and no further producer is built.
+func FlatMapParallel(maxConcurrent int, fn func(stream.T) stream.Producer) stream.Transformer {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	return &flatMapParallel{
+		maxConcurrent: maxConcurrent,
+		fn:            fn,
+	}
+}
+
+func (transformer *flatMapParallel) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *flatMapParallel) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+	slots := make(chan struct{}, transformer.maxConcurrent)
+
+	drain := func(inner stream.Readable) {
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case item, more := <-inner:
+				if !more {
+					return
+				}
+				emitter.Emit(item)
+			}
+		}
+	}
+
+	go func() {
+		var wg sync.WaitGroup
+		// Inner streams may still be emitting, so wait for them before
+		// closing the stream
+		defer close(writable)
+		defer wg.Wait()
+		defer transformer.context.Recover()
+
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case slots <- struct{}{}:
+			}
+
+			var data stream.T
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case item, more := <-in:
+				if !more {
+					return
+				}
+				data = item
+			}
+
+			producer := transformer.fn(data)
+			producer.Attach(transformer.context)
+			inner := producer.Produce()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer transformer.context.Recover()
+				defer func() { <-slots }()
+				drain(inner)
+			}()
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,98 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlatMapParallel(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			close(out)
+
+			Convey("When I apply the transformer with bounded concurrency", func() {
+				var active, peak int64
+				transformer := transformers.FlatMapParallel(2, func(data stream.T) stream.Producer {
+					return &producers.Observable{
+						Emit: func(emitter stream.Emitter) {
+							current := atomic.AddInt64(&active, 1)
+							defer atomic.AddInt64(&active, -1)
+							for {
+								max := atomic.LoadInt64(&peak)
+								if current <= max || atomic.CompareAndSwapInt64(&peak, max, current) {
+									break
+								}
+							}
+
+							time.Sleep(10 * time.Millisecond)
+							emitter.Emit(data)
+							emitter.Emit(data)
+						},
+					}
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then every inner stream is merged downstream", func() {
+					items := next.ReadAll()
+					So(items, ShouldHaveLength, 8)
+					for i := 1; i <= 4; i++ {
+						So(items, ShouldContain, i)
+					}
+
+					Convey("And at most the given number of inner streams run at once", func() {
+						So(atomic.LoadInt64(&peak), ShouldBeLessThanOrEqualTo, 2)
+					})
+				})
+			})
+
+			Convey("When an inner stream fails", func() {
+				failure := errors.New("inner failure")
+				transformer := transformers.FlatMapParallel(2, func(data stream.T) stream.Producer {
+					return &producers.Observable{
+						Emit: func(emitter stream.Emitter) {
+							panic(failure)
+						},
+					}
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the error is propagated and the expansion stopped", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.FlatMapParallel(2, func(data stream.T) stream.Producer {
+						return producers.FromRange(1, data.(int))
+					})
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFlatMap(t *testing.T) {
+	upTo := func(data stream.T) stream.Producer { return producers.FromRange(1, data.(int)) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.FlatMap(upTo)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then each item is expanded into its inner stream in order", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 1, 2, 1, 2, 3})
+				})
+			})
+
+			Convey("When an inner stream fails", func() {
+				failure := errors.New("inner failure")
+				transformer := transformers.FlatMap(func(data stream.T) stream.Producer {
+					return &producers.Observable{
+						Emit: func(emitter stream.Emitter) {
+							emitter.Emit(data)
+							if data == 2 {
+								panic(failure)
+							}
+						},
+					}
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the error is propagated and the expansion stopped", func() {
+					items := next.ReadAll()
+					So(items, ShouldNotContain, 3)
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.FlatMap(upTo)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -29,6 +29,17 @@ func TestFlatten(t *testing.T) {
 				})
 			})
 
+			Convey("When I apply the strict transformer to the stream", func() {
+				transformer := transformers.FlattenStrict()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed on the non slice item", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+					So(context.Err(), ShouldEqual, transformers.ErrNoSuchSlice)
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
@@ -43,5 +54,21 @@ func TestFlatten(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("And a stream of nested slices", func() {
+			in, out := stream.New(1)
+			out <- []stream.T{[]stream.T{1, 2}, 3}
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.FlattenStrict()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then slices are flattened one level only", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{1, 2}, 3})
+				})
+			})
+		})
 	})
 }
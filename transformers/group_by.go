@@ -0,0 +1,78 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type GroupStream struct {
+	Key   stream.T
+	Items stream.Readable
+}
+
+type groupBy struct {
+	context stream.Context
+	key     stream.MapFn
+}
+
+// GroupBy splits the stream into one sub-stream per key, emitting a
+// GroupStream as soon as the first item of its key arrives. Sub-streams
+// share the transformer's context and close once upstream completes or
+// the context is closed, right before the stream of groups itself closes.
+// Each sub-stream buffers up to the upstream capacity, past that routing
+// blocks until it is read, so every emitted group must be drained, or the
+// context closed, for the other groups and the stream to make progress.
+func GroupBy(key stream.MapFn) stream.Transformer {
+	return &groupBy{
+		key: key,
+	}
+}
+
+func (transformer *groupBy) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *groupBy) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+
+	go func() {
+		defer close(writable)
+
+		groups := make(map[stream.T]stream.Writable)
+		emitters := make(map[stream.T]stream.Emitter)
+		defer func() {
+			for _, group := range groups {
+				close(group)
+			}
+		}()
+		defer transformer.context.Recover()
+
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				key := transformer.key(data)
+				if _, exists := groups[key]; !exists {
+					items, group := stream.New(in.Capacity())
+					groups[key] = group
+					emitters[key] = stream.NewEmitter(transformer.context, group)
+					emitter.Emit(GroupStream{Key: key, Items: items})
+				}
+
+				emitters[key].Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
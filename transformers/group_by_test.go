@@ -0,0 +1,60 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	parity := func(data stream.T) stream.T { return data.(int) % 2 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.GroupBy(parity)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are delivered by their key's sub-stream", func() {
+					odds := (<-next).(transformers.GroupStream)
+					evens := (<-next).(transformers.GroupStream)
+					So(odds.Key, ShouldEqual, 1)
+					So(evens.Key, ShouldEqual, 0)
+					So(odds.Items.ReadAll(), ShouldResemble, []stream.T{1, 3, 5})
+					So(evens.Items.ReadAll(), ShouldResemble, []stream.T{2, 4})
+
+					Convey("And the stream of groups closes once upstream completes", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.GroupBy(parity)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no group is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package transformers
+
+import (
+	"container/heap"
+	"github.com/drborges/rivers/stream"
+	"sort"
+)
+
+type boundedHeap struct {
+	size  int
+	less  func(a, b stream.T) bool
+	items []stream.T
+}
+
+func (h *boundedHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *boundedHeap) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h *boundedHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *boundedHeap) Push(data interface{}) {
+	h.items = append(h.items, data)
+}
+
+func (h *boundedHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// Offer keeps data only if it ranks among the heap's top items
+func (h *boundedHeap) Offer(data stream.T) {
+	if h.Len() < h.size {
+		heap.Push(h, data)
+		return
+	}
+
+	if h.size > 0 && h.less(h.items[0], data) {
+		h.items[0] = data
+		heap.Fix(h, 0)
+	}
+}
+
+// Top returns the kept items from the highest to the lowest ranked
+func (h *boundedHeap) Top() []stream.T {
+	top := make([]stream.T, len(h.items))
+	copy(top, h.items)
+	sort.SliceStable(top, func(i, j int) bool {
+		return h.less(top[j], top[i])
+	})
+	return top
+}
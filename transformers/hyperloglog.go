@@ -0,0 +1,67 @@
+package transformers
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const hyperLogLogPrecision = 14
+
+// hyperLogLog estimates the number of distinct keys added to it within
+// a standard error of about 0.8%, using 16KB regardless of cardinality
+type hyperLogLog struct {
+	registers []uint8
+}
+
+func newHyperLogLog() *hyperLogLog {
+	return &hyperLogLog{
+		registers: make([]uint8, 1<<hyperLogLogPrecision),
+	}
+}
+
+func (hll *hyperLogLog) add(key string) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := mix64(h.Sum64())
+
+	index := x >> (64 - hyperLogLogPrecision)
+	rank := uint8(bits.LeadingZeros64(x<<hyperLogLogPrecision|1<<(hyperLogLogPrecision-1)) + 1)
+	if rank > hll.registers[index] {
+		hll.registers[index] = rank
+	}
+}
+
+func (hll *hyperLogLog) estimate() uint64 {
+	m := float64(len(hll.registers))
+	sum, zeros := 0.0, 0
+	for _, register := range hll.registers {
+		sum += 1 / float64(uint64(1)<<register)
+		if register == 0 {
+			zeros++
+		}
+	}
+
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+func (hll *hyperLogLog) reset() {
+	for i := range hll.registers {
+		hll.registers[i] = 0
+	}
+}
+
+// mix64 spreads fnv's poorly distributed bits across the whole hash
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
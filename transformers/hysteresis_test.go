@@ -0,0 +1,58 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestHysteresis(t *testing.T) {
+	aboveThreshold := func(d stream.T) bool { return d.(int) > 5 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of flapping data", func() {
+			in, out := stream.New(8)
+			out <- 1
+			out <- 6
+			out <- 2
+			out <- 7
+			out <- 3
+			out <- 4
+			out <- 5
+			out <- 9
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Hysteresis(aboveThreshold, 3)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only state transitions are emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.HysteresisState{On: true, Data: 6},
+						transformers.HysteresisState{On: false, Data: 5},
+						transformers.HysteresisState{On: true, Data: 9},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Hysteresis(aboveThreshold, 3)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/producers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestLimit(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data that is not yet closed", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Limit(2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the stream is closed right after the limit is reached", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Limit(2)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And an infinite stream of data", func() {
+			producer := producers.FromTicker(time.Millisecond)
+			producer.Attach(context)
+			in := producer.Produce()
+
+			Convey("When I limit it to zero items", func() {
+				transformer := transformers.Limit(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the stream is closed right away stopping upstream", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldBeNil)
+					<-context.Done()
+				})
+			})
+		})
+	})
+}
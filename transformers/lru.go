@@ -0,0 +1,63 @@
+package transformers
+
+import (
+	"container/list"
+	"github.com/drborges/rivers/stream"
+	"sync"
+)
+
+type lruEntry struct {
+	key   stream.T
+	value stream.T
+}
+
+// lruCache keeps up to size entries evicting the least recently used
+// ones, safe for parallel stages sharing it
+type lruCache struct {
+	mutex   sync.Mutex
+	size    int
+	order   *list.List
+	entries map[stream.T]*list.Element
+}
+
+func newLRUCache(size int) *lruCache {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &lruCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[stream.T]*list.Element),
+	}
+}
+
+func (cache *lruCache) get(key stream.T) (stream.T, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, exists := cache.entries[key]
+	if !exists {
+		return nil, false
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*lruEntry).value, true
+}
+
+func (cache *lruCache) put(key, value stream.T) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, exists := cache.entries[key]; exists {
+		element.Value.(*lruEntry).value = value
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(&lruEntry{key, value})
+	if cache.order.Len() > cache.size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*lruEntry).key)
+	}
+}
@@ -0,0 +1,57 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestLSHBucket(t *testing.T) {
+	signatures := map[stream.T]uint64{
+		"a": 0x0000000100000002,
+		"b": 0x0000000100000003,
+	}
+	hash := func(data stream.T) uint64 { return signatures[data] }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of similar items", func() {
+			in, out := stream.New(2)
+			out <- "a"
+			out <- "b"
+			close(out)
+
+			Convey("When I apply the transformer with two bands", func() {
+				transformer := transformers.LSHBucket(hash, 2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then each item is tagged once per band sharing the bucket of the matching band", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.LSHBucketed{Bucket: 2, Item: "a"},
+						transformers.LSHBucketed{Bucket: 1<<58 | 1, Item: "a"},
+						transformers.LSHBucketed{Bucket: 3, Item: "b"},
+						transformers.LSHBucketed{Bucket: 1<<58 | 1, Item: "b"},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.LSHBucket(hash, 2)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
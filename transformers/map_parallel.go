@@ -0,0 +1,131 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"sync"
+	"time"
+)
+
+type sequenced struct {
+	seq  int
+	data stream.T
+}
+
+type mapParallel struct {
+	context stream.Context
+	workers int
+	fn      stream.MapFn
+}
+
+// MapParallel maps items with fn across a pool of workers, emitting results
+// in the order their items arrived. Results finished ahead of slower ones
+// are buffered for reordering, at most workers plus the stream capacity of
+// them, so a slow item holds back the workers once that window is full.
+func MapParallel(workers int, fn stream.MapFn) stream.Transformer {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	return &mapParallel{
+		workers: workers,
+		fn:      fn,
+	}
+}
+
+func (transformer *mapParallel) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *mapParallel) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	jobs := make(chan sequenced, in.Capacity())
+	results := make(chan sequenced, in.Capacity())
+	window := make(chan struct{}, transformer.workers+in.Capacity())
+
+	go func() {
+		defer close(jobs)
+		defer transformer.context.Recover()
+
+		for seq := 0; ; seq++ {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case window <- struct{}{}:
+			}
+
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+				select {
+				case <-transformer.context.Failure():
+					return
+				case <-transformer.context.Done():
+					return
+				case jobs <- sequenced{seq, data}:
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < transformer.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer transformer.context.Recover()
+
+			for job := range jobs {
+				result := sequenced{job.seq, transformer.fn(job.data)}
+				select {
+				case <-transformer.context.Failure():
+					return
+				case <-transformer.context.Done():
+					return
+				case results <- result:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	go func() {
+		defer close(writable)
+		defer transformer.context.Recover()
+
+		emitter := stream.NewEmitter(transformer.context, writable)
+		pending := make(map[int]stream.T)
+		next := 0
+
+		for result := range results {
+			pending[result.seq] = result.data
+			for {
+				data, ready := pending[next]
+				if !ready {
+					break
+				}
+				delete(pending, next)
+				next++
+				emitter.Emit(data)
+				<-window
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,81 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestMapParallel(t *testing.T) {
+	slowerFirst := func(data stream.T) stream.T {
+		time.Sleep(time.Duration(10-data.(int)) * time.Millisecond)
+		return data.(int) * 2
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			for i := 1; i <= 5; i++ {
+				out <- i
+			}
+			close(out)
+
+			Convey("When I apply the transformer with items finishing out of order", func() {
+				transformer := transformers.MapParallel(3, slowerFirst)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then results are emitted in the input order", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{2, 4, 6, 8, 10})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When the mapping function panics", func() {
+				transformer := transformers.MapParallel(3, func(data stream.T) stream.T {
+					panic("map failure")
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the recovered error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldEqual, "Recovered from map failure")
+				})
+			})
+
+			Convey("When the mapping function panics on every worker", func() {
+				transformer := transformers.MapParallel(5, func(data stream.T) stream.T {
+					time.Sleep(time.Millisecond)
+					panic("map failure")
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the stream is still closed with the recovered error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldEqual, "Recovered from map failure")
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.MapParallel(3, slowerFirst)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
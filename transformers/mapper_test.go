@@ -1,6 +1,7 @@
 package transformers_test
 
 import (
+	"errors"
 	"github.com/drborges/rivers"
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
@@ -30,6 +31,18 @@ func TestMapper(t *testing.T) {
 				})
 			})
 
+			Convey("When the mapping function panics", func() {
+				failure := errors.New("mapping failed")
+				transformer := transformers.Map(func(d stream.T) stream.T { panic(failure) })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the stream is closed with the panic error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
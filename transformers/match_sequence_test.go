@@ -0,0 +1,76 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestMatchSequence(t *testing.T) {
+	is := func(event string) stream.PredicateFn {
+		return func(data stream.T) bool { return data == event }
+	}
+	patterns := []stream.PredicateFn{is("login"), is("login"), is("purchase")}
+	join := func(matched []stream.T) stream.T {
+		events := []string{}
+		for _, event := range matched {
+			events = append(events, event.(string))
+		}
+		return strings.Join(events, ",")
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of events", func() {
+			in, out := stream.New(8)
+			out <- "login"
+			out <- "login"
+			out <- "login"
+			out <- "purchase"
+			out <- "logout"
+			out <- "login"
+			out <- "purchase"
+			out <- "purchase"
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.MatchSequence(patterns, join)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only complete runs of consecutive events are matched", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"login,login,purchase"})
+				})
+			})
+
+			Convey("When I apply the transformer without patterns", func() {
+				transformer := transformers.MatchSequence(nil, join)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrNoPatterns)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.MatchSequence(patterns, join)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -20,8 +20,10 @@ func (observer *Observer) Transform(in stream.Readable) stream.Readable {
 	emitter := stream.NewEmitter(observer.context, writable)
 
 	go func() {
-		defer observer.context.Recover()
+		// Recover before closing the stream so that downstream
+		// stages are aware of failures by the time they see it closed
 		defer close(writable)
+		defer observer.context.Recover()
 
 		for {
 			select {
@@ -34,6 +36,12 @@ func (observer *Observer) Transform(in stream.Readable) stream.Readable {
 			default:
 				data, more := <-in
 				if !more {
+					select {
+					case <-observer.context.Failure():
+						return
+					default:
+					}
+
 					if observer.OnCompleted != nil {
 						observer.OnCompleted(emitter)
 					}
@@ -0,0 +1,92 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type onError struct {
+	context    stream.Context
+	downstream stream.Context
+	handler    func(error) stream.T
+}
+
+// OnError forwards items into the downstream context, a context other than
+// the one the transformer is attached to, so that a failure upstream does
+// not reach the stages downstream: the item built by handler out of the
+// upstream error is emitted instead and the stream closes gracefully. A
+// panic in handler closes the downstream context with it, and closing the
+// downstream context stops upstream stages as well.
+func OnError(handler func(error) stream.T, downstream stream.Context) stream.Transformer {
+	return &onError{
+		downstream: downstream,
+		handler:    handler,
+	}
+}
+
+func (transformer *onError) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *onError) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.downstream, writable)
+
+	go func() {
+		defer close(writable)
+		defer transformer.downstream.Recover()
+		// runs before Recover so that upstream is stopped even when emitting
+		// panics because the downstream context got closed
+		defer transformer.stopUpstream()
+
+		for {
+			// gives the downstream context priority over items ready to be read
+			select {
+			case <-transformer.downstream.Failure():
+				return
+			case <-transformer.downstream.Done():
+				return
+			default:
+			}
+
+			select {
+			case <-transformer.downstream.Failure():
+				return
+			case <-transformer.downstream.Done():
+				return
+			case <-time.After(transformer.downstream.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					select {
+					case <-transformer.context.Failure():
+						emitter.Emit(transformer.handler(transformer.context.Err()))
+					default:
+					}
+					return
+				}
+				emitter.Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
+
+// stopUpstream closes the upstream context, unless it is already closed, once
+// the downstream context is closed.
+func (transformer *onError) stopUpstream() {
+	select {
+	case <-transformer.downstream.Failure():
+	case <-transformer.downstream.Done():
+	default:
+		return
+	}
+
+	select {
+	case <-transformer.context.Failure():
+	case <-transformer.context.Done():
+	default:
+		transformer.context.Close(nil)
+	}
+}
@@ -0,0 +1,74 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestOnError(t *testing.T) {
+	fallback := func(err error) stream.T { return "fallback: " + err.Error() }
+
+	Convey("Given I have an upstream and a downstream context", t, func() {
+		upstream := rivers.NewContext()
+		downstream := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+			close(out)
+
+			Convey("When the upstream completes gracefully", func() {
+				transformer := transformers.OnError(fallback, downstream)
+				transformer.Attach(upstream)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded without fallback", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2})
+					So(downstream.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When the upstream fails", func() {
+				upstream.Close(errors.New("upstream failure"))
+				transformer := transformers.OnError(fallback, downstream)
+				transformer.Attach(upstream)
+				next := transformer.Transform(in)
+
+				Convey("Then the fallback is emitted and the downstream closes cleanly", func() {
+					items := next.ReadAll()
+					So(items[len(items)-1], ShouldEqual, "fallback: upstream failure")
+					So(downstream.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When the upstream fails and the handler panics", func() {
+				upstream.Close(errors.New("upstream failure"))
+				transformer := transformers.OnError(func(err error) stream.T { panic(err) }, downstream)
+				transformer.Attach(upstream)
+				next := transformer.Transform(in)
+				next.ReadAll()
+
+				Convey("Then the downstream context is closed with the panic", func() {
+					So(downstream.Err().Error(), ShouldEqual, "upstream failure")
+				})
+			})
+
+			Convey("When I close the downstream context", func() {
+				downstream.Close(stream.Done)
+				transformer := transformers.OnError(fallback, downstream)
+				transformer.Attach(upstream)
+				next := transformer.Transform(in)
+
+				Convey("Then the upstream is stopped as well", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					<-upstream.Done()
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package transformers
+
+import (
+	"fmt"
+	"github.com/drborges/rivers/stream"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+type orderedByKey struct {
+	context stream.Context
+	key     stream.MapFn
+	workers int
+	fn      stream.MapFn
+}
+
+func OrderedByKey(key stream.MapFn, workers int, fn stream.MapFn) stream.Transformer {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	return &orderedByKey{
+		key:     key,
+		workers: workers,
+		fn:      fn,
+	}
+}
+
+func (transformer *orderedByKey) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *orderedByKey) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	queues := make([]chan stream.T, transformer.workers)
+
+	var wg sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan stream.T, in.Capacity())
+		wg.Add(1)
+		go transformer.work(queues[i], writable, &wg)
+	}
+
+	go func() {
+		defer transformer.context.Recover()
+		defer func() {
+			for _, queue := range queues {
+				close(queue)
+			}
+		}()
+
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				select {
+				case <-transformer.context.Failure():
+					return
+				case <-transformer.context.Done():
+					return
+				case queues[transformer.worker(data)] <- data:
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(writable)
+		wg.Wait()
+	}()
+
+	return readable
+}
+
+func (transformer *orderedByKey) worker(data stream.T) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(fmt.Sprintf("%v", transformer.key(data))))
+	return int(hash.Sum32() % uint32(transformer.workers))
+}
+
+func (transformer *orderedByKey) work(queue chan stream.T, writable stream.Writable, wg *sync.WaitGroup) {
+	defer wg.Done()
+	defer transformer.context.Recover()
+
+	emitter := stream.NewEmitter(transformer.context, writable)
+	for data := range queue {
+		emitter.Emit(transformer.fn(data))
+	}
+}
@@ -0,0 +1,73 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestOrderedByKey(t *testing.T) {
+	evensAndOdds := func(d stream.T) stream.T { return d.(int) % 2 }
+	inc := func(d stream.T) stream.T { return d.(int) + 1 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(6)
+			for i := 1; i <= 6; i++ {
+				out <- i
+			}
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.OrderedByKey(evensAndOdds, 2, inc)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items sharing a key are transformed in order", func() {
+					var evens, odds []stream.T
+					for _, data := range next.ReadAll() {
+						if data.(int)%2 == 0 {
+							odds = append(odds, data)
+						} else {
+							evens = append(evens, data)
+						}
+					}
+
+					So(odds, ShouldResemble, []stream.T{2, 4, 6})
+					So(evens, ShouldResemble, []stream.T{3, 5, 7})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.OrderedByKey(evensAndOdds, 2, inc)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+
+			Convey("When the function panics", func() {
+				transformer := transformers.OrderedByKey(evensAndOdds, 2, func(stream.T) stream.T {
+					panic("process failure")
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the panic before the stream is", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldEqual, "Recovered from process failure")
+				})
+			})
+		})
+	})
+}
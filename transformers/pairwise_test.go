@@ -0,0 +1,63 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestPairwise(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- "a"
+			out <- "b"
+			out <- "c"
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Pairwise()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then consecutive overlapping pairs are emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[2]stream.T{"a", "b"}, [2]stream.T{"b", "c"}})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Pairwise()
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no pair is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And a single item stream", func() {
+			in, out := stream.New(1)
+			out <- "a"
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Pairwise()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no pair is emitted", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type partition struct {
+	context stream.Context
+	fn      stream.PredicateFn
+}
+
+// Partitioner splits a stream in two, see Partition
+type Partitioner interface {
+	stream.Attachable
+	Partition(in stream.Readable) (matching, nonMatching stream.Readable)
+}
+
+// Partition splits a stream in two, items satisfying fn go to the
+// matching stream and the others to the non matching one.
+func Partition(fn stream.PredicateFn) Partitioner {
+	return &partition{
+		fn: fn,
+	}
+}
+
+func (partition *partition) Attach(context stream.Context) {
+	partition.context = context
+}
+
+// Partition routes the items of in preserving their order within each side.
+// Each side queues items not yet read in memory, so a side that is never
+// read does not block the other one, at the cost of keeping all its items.
+// Both sides close once in is done and their queues drained, or right away
+// once the context is closed.
+func (partition *partition) Partition(in stream.Readable) (matching, nonMatching stream.Readable) {
+	matchingFeed := make(chan stream.T)
+	nonMatchingFeed := make(chan stream.T)
+
+	go func() {
+		// Recover before closing the feeds so that downstream
+		// stages are aware of failures by the time they see them closed
+		defer close(matchingFeed)
+		defer close(nonMatchingFeed)
+		defer partition.context.Recover()
+
+		for {
+			select {
+			case <-partition.context.Failure():
+				return
+			case <-partition.context.Done():
+				return
+			case <-time.After(partition.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				feed := nonMatchingFeed
+				if partition.fn(data) {
+					feed = matchingFeed
+				}
+
+				select {
+				case <-partition.context.Failure():
+					return
+				case <-partition.context.Done():
+					return
+				case feed <- data:
+				}
+			}
+		}
+	}()
+
+	return queue(partition.context, matchingFeed, in.Capacity()), queue(partition.context, nonMatchingFeed, in.Capacity())
+}
+
+// queue forwards items from feed always being ready to receive them,
+// queueing the ones not yet read downstream. The returned stream closes
+// once feed is closed and the queue drained, or as soon as the context is
+// closed.
+func queue(context stream.Context, feed chan stream.T, capacity int) stream.Readable {
+	readable, writable := stream.New(capacity)
+
+	go func() {
+		defer close(writable)
+
+		var queued []stream.T
+		for feed != nil || len(queued) > 0 {
+			var out stream.Writable
+			var next stream.T
+			if len(queued) > 0 {
+				out, next = writable, queued[0]
+			}
+
+			select {
+			case <-context.Failure():
+				return
+			case <-context.Done():
+				return
+			case data, more := <-feed:
+				if !more {
+					feed = nil
+					continue
+				}
+				queued = append(queued, data)
+			case out <- next:
+				queued = queued[1:]
+			}
+		}
+	}()
+
+	return readable
+}
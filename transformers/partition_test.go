@@ -0,0 +1,71 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	evens := func(data stream.T) bool { return data.(int)%2 == 0 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(1)
+			go func() {
+				defer close(out)
+				for i := 1; i <= 10; i++ {
+					out <- i
+				}
+			}()
+
+			Convey("When I partition the stream", func() {
+				partition := transformers.Partition(evens)
+				partition.Attach(context)
+				matching, nonMatching := partition.Partition(in)
+
+				Convey("Then items are routed to their side in order", func() {
+					So(nonMatching.ReadAll(), ShouldResemble, []stream.T{1, 3, 5, 7, 9})
+					So(matching.ReadAll(), ShouldResemble, []stream.T{2, 4, 6, 8, 10})
+				})
+
+				Convey("Then one side is not blocked by the other not being read", func() {
+					So(matching.ReadAll(), ShouldResemble, []stream.T{2, 4, 6, 8, 10})
+				})
+			})
+
+			Convey("When the predicate panics", func() {
+				partition := transformers.Partition(func(data stream.T) bool {
+					panic("partition failure")
+				})
+				partition.Attach(context)
+				matching, nonMatching := partition.Partition(in)
+
+				Convey("Then both sides are closed with the recovered error", func() {
+					So(matching.ReadAll(), ShouldBeEmpty)
+					So(nonMatching.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldEqual, "Recovered from partition failure")
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I partition the stream", func() {
+					partition := transformers.Partition(evens)
+					partition.Attach(context)
+					matching, nonMatching := partition.Partition(in)
+
+					Convey("Then both sides are closed", func() {
+						So(matching.ReadAll(), ShouldBeEmpty)
+						So(nonMatching.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		reported := []float64{}
+		report := func(percent float64) { reported = append(reported, percent) }
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the transformer with the stream's total", func() {
+				transformer := transformers.Progress(4, report)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded unchanged", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 4, 5})
+
+					Convey("And the completion percentage is reported clamped at 100%", func() {
+						So(reported, ShouldResemble, []float64{25, 50, 75, 100, 100})
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Progress(5, report)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no progress is reported", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+						So(reported, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,128 @@
+package transformers
+
+import (
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+var ErrInvalidRate = errors.New("Rate must be greater than zero tokens per second")
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type rateLimitByKey struct {
+	context   stream.Context
+	key       stream.MapFn
+	rate      float64
+	burst     int
+	drop      bool
+	buckets   map[stream.T]*bucket
+	lastSweep time.Time
+}
+
+// RateLimitByKey limits items to rate per second for each key computed by
+// key, allowing bursts of up to burst items. A rate not greater than zero
+// closes the context with ErrInvalidRate.
+func RateLimitByKey(key stream.MapFn, rate float64, burst int) *rateLimitByKey {
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &rateLimitByKey{
+		key:     key,
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[stream.T]*bucket),
+	}
+}
+
+// DropExceeding discards items whose key is out of tokens instead of waiting for them
+func (limiter *rateLimitByKey) DropExceeding() stream.Transformer {
+	limiter.drop = true
+	return limiter
+}
+
+func (limiter *rateLimitByKey) Attach(context stream.Context) {
+	limiter.context = context
+}
+
+func (limiter *rateLimitByKey) Transform(in stream.Readable) stream.Readable {
+	if limiter.rate <= 0 {
+		invalid := &empty{err: ErrInvalidRate}
+		invalid.Attach(limiter.context)
+		return invalid.Transform(in)
+	}
+
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			b := limiter.bucketFor(limiter.key(data))
+			for !limiter.take(b) {
+				if limiter.drop {
+					return nil
+				}
+
+				wait := time.Duration((1 - b.tokens) / limiter.rate * float64(time.Second))
+				select {
+				case <-limiter.context.Failure():
+					return nil
+				case <-limiter.context.Done():
+					return nil
+				case <-time.After(wait):
+				}
+			}
+
+			emitter.Emit(data)
+			return nil
+		},
+	}
+
+	observer.Attach(limiter.context)
+	return observer.Transform(in)
+}
+
+func (limiter *rateLimitByKey) bucketFor(key stream.T) *bucket {
+	now := time.Now()
+	limiter.evictIdle(now)
+
+	b, exists := limiter.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(limiter.burst), last: now}
+		limiter.buckets[key] = b
+	}
+	return b
+}
+
+func (limiter *rateLimitByKey) take(b *bucket) bool {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * limiter.rate
+	if b.tokens > float64(limiter.burst) {
+		b.tokens = float64(limiter.burst)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// evictIdle drops buckets that had enough time to refill, since
+// they behave just like brand new ones
+func (limiter *rateLimitByKey) evictIdle(now time.Time) {
+	refill := time.Duration(float64(limiter.burst) / limiter.rate * float64(time.Second))
+	if now.Sub(limiter.lastSweep) < refill {
+		return
+	}
+
+	for key, b := range limiter.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(limiter.buckets, key)
+		}
+	}
+	limiter.lastSweep = now
+}
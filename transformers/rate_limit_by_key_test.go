@@ -0,0 +1,75 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestRateLimitByKey(t *testing.T) {
+	tenant := func(d stream.T) stream.T { return d.(string)[:1] }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- "a1"
+			out <- "a2"
+			out <- "a3"
+			out <- "b1"
+			out <- "b2"
+			close(out)
+
+			Convey("When I apply the transformer dropping exceeding items", func() {
+				transformer := transformers.RateLimitByKey(tenant, 1, 2).DropExceeding()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items over each key's burst are dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a1", "a2", "b1", "b2"})
+				})
+			})
+
+			Convey("When I apply the transformer waiting for tokens", func() {
+				transformer := transformers.RateLimitByKey(tenant, 10, 2)
+				transformer.Attach(context)
+				start := time.Now()
+				next := transformer.Transform(in)
+
+				Convey("Then all items are emitted once tokens are replenished", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a1", "a2", "a3", "b1", "b2"})
+					So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 90*time.Millisecond)
+				})
+			})
+
+			Convey("When I apply the transformer with an invalid rate", func() {
+				transformer := transformers.RateLimitByKey(tenant, 0, 2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidRate)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.RateLimitByKey(tenant, 10, 2)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package transformers_test
 
 import (
+	"errors"
 	"github.com/drborges/rivers"
 	"github.com/drborges/rivers/stream"
 	"github.com/drborges/rivers/transformers"
@@ -31,6 +32,19 @@ func TestReducer(t *testing.T) {
 				})
 			})
 
+			Convey("When the upstream fails before closing", func() {
+				failure := errors.New("upstream failure")
+				context.Close(failure)
+				transformer := transformers.Reduce(0, sum)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the accumulated value is not emitted and the error is kept", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, failure)
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
@@ -45,5 +59,20 @@ func TestReducer(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("And an empty stream", func() {
+			in, out := stream.New(0)
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Reduce(0, sum)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only the seed is emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{0})
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,76 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	flaky := errors.New("flaky")
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		calls := map[stream.T]int{}
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+			close(out)
+
+			Convey("When I retry a function failing once per item", func() {
+				transformer := transformers.Retry(1, func(data stream.T) (stream.T, error) {
+					calls[data]++
+					if calls[data] == 1 {
+						return nil, flaky
+					}
+					return data.(int) * 10, nil
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then every item is mapped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{10, 20})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When a later item keeps failing", func() {
+				transformer := transformers.Retry(2, func(data stream.T) (stream.T, error) {
+					calls[data]++
+					if data == 2 {
+						return nil, flaky
+					}
+					return data.(int) * 10, nil
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items mapped so far are forwarded before the stream fails", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{10})
+					So(calls[2], ShouldEqual, 3)
+					So(context.Err(), ShouldEqual, flaky)
+				})
+			})
+
+			Convey("When I close the context while backing off", func() {
+				transformer := transformers.Retry(1, func(data stream.T) (stream.T, error) {
+					return nil, flaky
+				}).Backoff(time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+				time.Sleep(10 * time.Millisecond)
+				context.Close(stream.Done)
+
+				Convey("Then the stream is closed right away", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how RetryWithPolicy backs off between attempts.
+// Delays start at BaseDelay growing by Multiplier (2 when unset) up to
+// MaxDelay, if set, and are randomly shortened by up to the Jitter
+// fraction. RetryIf, when set, tells which errors are worth retrying.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      float64
+	Multiplier  float64
+	RetryIf     func(error) bool
+}
+
+func (policy RetryPolicy) delay(attempt int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := float64(policy.BaseDelay)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if policy.MaxDelay > 0 && delay > float64(policy.MaxDelay) {
+			break
+		}
+	}
+
+	if policy.MaxDelay > 0 && delay > float64(policy.MaxDelay) {
+		delay = float64(policy.MaxDelay)
+	}
+
+	if policy.Jitter > 0 {
+		delay -= delay * policy.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+type retryWithPolicy struct {
+	context stream.Context
+	fn      func(stream.T) (stream.T, error)
+	policy  RetryPolicy
+}
+
+// RetryWithPolicy maps items with fn retrying failed calls according to
+// policy. The context is closed with the last error once attempts run
+// out, or right away with errors the policy does not retry.
+func RetryWithPolicy(fn func(stream.T) (stream.T, error), policy RetryPolicy) stream.Transformer {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+
+	return &retryWithPolicy{
+		fn:     fn,
+		policy: policy,
+	}
+}
+
+// Retry maps items with fn retrying failed calls up to attempts times, so
+// fn runs at most attempts + 1 times per item, right away unless a backoff
+// is given, see Backoff. Items mapped before an item runs out of attempts
+// are forwarded, the context is then closed with that item's last error.
+func Retry(attempts int, fn func(stream.T) (stream.T, error)) *retryWithPolicy {
+	return &retryWithPolicy{
+		fn:     fn,
+		policy: RetryPolicy{MaxAttempts: attempts + 1, Multiplier: 1},
+	}
+}
+
+// Backoff waits d between attempts
+func (transformer *retryWithPolicy) Backoff(d time.Duration) *retryWithPolicy {
+	transformer.policy.BaseDelay = d
+	return transformer
+}
+
+func (transformer *retryWithPolicy) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *retryWithPolicy) Transform(in stream.Readable) stream.Readable {
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			for attempt := 1; ; attempt++ {
+				result, err := transformer.fn(data)
+				if err == nil {
+					emitter.Emit(result)
+					return nil
+				}
+
+				if attempt >= transformer.policy.MaxAttempts {
+					return err
+				}
+
+				if transformer.policy.RetryIf != nil && !transformer.policy.RetryIf(err) {
+					return err
+				}
+
+				select {
+				case <-transformer.context.Failure():
+					return nil
+				case <-transformer.context.Done():
+					return nil
+				case <-time.After(transformer.policy.delay(attempt)):
+				}
+			}
+		},
+	}
+
+	observer.Attach(transformer.context)
+	return observer.Transform(in)
+}
@@ -0,0 +1,102 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestRetryWithPolicy(t *testing.T) {
+	transient := errors.New("transient")
+	permanent := errors.New("permanent")
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		attempts := 0
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(1)
+			out <- 1
+			close(out)
+
+			Convey("When I apply the transformer with a function that fails twice", func() {
+				transformer := transformers.RetryWithPolicy(func(data stream.T) (stream.T, error) {
+					attempts++
+					if attempts < 3 {
+						return nil, transient
+					}
+					return data.(int) * 10, nil
+				}, transformers.RetryPolicy{MaxAttempts: 3, BaseDelay: 20 * time.Millisecond, Multiplier: 2})
+				transformer.Attach(context)
+
+				start := time.Now()
+				next := transformer.Transform(in)
+				items := next.ReadAll()
+				elapsed := time.Since(start)
+
+				Convey("Then the item is mapped after backing off exponentially", func() {
+					So(items, ShouldResemble, []stream.T{10})
+					So(attempts, ShouldEqual, 3)
+					So(elapsed, ShouldBeGreaterThanOrEqualTo, 60*time.Millisecond)
+					So(elapsed, ShouldBeLessThan, 200*time.Millisecond)
+				})
+			})
+
+			Convey("When I apply the transformer with a function that keeps failing", func() {
+				transformer := transformers.RetryWithPolicy(func(data stream.T) (stream.T, error) {
+					attempts++
+					return nil, transient
+				}, transformers.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the last error once attempts run out", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(attempts, ShouldEqual, 3)
+					So(context.Err(), ShouldEqual, transient)
+				})
+			})
+
+			Convey("When the function fails with an error that is not retried", func() {
+				transformer := transformers.RetryWithPolicy(func(data stream.T) (stream.T, error) {
+					attempts++
+					return nil, permanent
+				}, transformers.RetryPolicy{
+					MaxAttempts: 3,
+					BaseDelay:   time.Hour,
+					RetryIf:     func(err error) bool { return err == transient },
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed right away", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(attempts, ShouldEqual, 1)
+					So(context.Err(), ShouldEqual, permanent)
+				})
+			})
+
+			Convey("When the context is closed while backing off", func() {
+				transformer := transformers.RetryWithPolicy(func(data stream.T) (stream.T, error) {
+					return nil, transient
+				}, transformers.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				go func() {
+					time.Sleep(50 * time.Millisecond)
+					context.Close(stream.Done)
+				}()
+
+				Convey("Then the back off is interrupted", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, stream.Done)
+				})
+			})
+		})
+	})
+}
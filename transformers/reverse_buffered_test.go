@@ -0,0 +1,46 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestReverseBuffered(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+
+			Convey("When I apply the transformer to the stream", func() {
+				close(out)
+				transformer := transformers.ReverseBuffered()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are emitted in reverse order", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{3, 2, 1})
+				})
+			})
+
+			Convey("When the upstream fails before closing", func() {
+				context.Close(errors.New("upstream failure"))
+				close(out)
+				transformer := transformers.ReverseBuffered()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no item is sent to the next stage", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"testing"
+)
+
+func TestSampleProbability(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(100)
+			for i := 0; i < 100; i++ {
+				out <- i
+			}
+			close(out)
+
+			Convey("When I apply the transformer with a seeded rng", func() {
+				transformer := transformers.SampleProbability(0.5, rand.New(rand.NewSource(1)))
+				transformer.Attach(context)
+				sampled := transformer.Transform(in).ReadAll()
+
+				Convey("Then a deterministic sample of the stream is returned", func() {
+					rng := rand.New(rand.NewSource(1))
+					expected := []stream.T{}
+					for i := 0; i < 100; i++ {
+						if rng.Float64() < 0.5 {
+							expected = append(expected, i)
+						}
+					}
+
+					So(sampled, ShouldResemble, expected)
+				})
+			})
+
+			Convey("When I apply the transformer with a zero probability", func() {
+				transformer := transformers.SampleProbability(0, rand.New(rand.NewSource(1)))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then all items are dropped", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When I apply the transformer with a probability of one", func() {
+				transformer := transformers.SampleProbability(1, rand.New(rand.NewSource(1)))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then all items are forwarded", func() {
+					So(len(next.ReadAll()), ShouldEqual, 100)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.SampleProbability(1, rand.New(rand.NewSource(1)))
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type snapshot struct {
+	context  stream.Context
+	reduce   stream.ReduceFn
+	seed     func() stream.T
+	interval time.Duration
+	copy     stream.MapFn
+}
+
+// Snapshot folds every item into an accumulator created by seed, emitting
+// the current accumulator every interval and once the stream completes.
+// Unlike windowed reductions the accumulator is never reset. Accumulators
+// mutated by reduce must be copied before being emitted, see CopyWith. An
+// interval not greater than zero closes the context with ErrInvalidInterval.
+func Snapshot(reduce stream.ReduceFn, seed func() stream.T, interval time.Duration) *snapshot {
+	return &snapshot{
+		reduce:   reduce,
+		seed:     seed,
+		interval: interval,
+		copy:     func(acc stream.T) stream.T { return acc },
+	}
+}
+
+// CopyWith emits the accumulator copied by fn so downstream stages do not
+// race with further reductions
+func (transformer *snapshot) CopyWith(fn stream.MapFn) *snapshot {
+	transformer.copy = fn
+	return transformer
+}
+
+func (transformer *snapshot) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *snapshot) Transform(in stream.Readable) stream.Readable {
+	if transformer.interval <= 0 {
+		invalid := &empty{err: ErrInvalidInterval}
+		invalid.Attach(transformer.context)
+		return invalid.Transform(in)
+	}
+
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer transformer.context.Recover()
+
+		ticker := time.NewTicker(transformer.interval)
+		defer ticker.Stop()
+
+		acc := transformer.seed()
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case <-ticker.C:
+				emitter.Emit(transformer.copy(acc))
+			case data, more := <-in:
+				if !more {
+					emitter.Emit(transformer.copy(acc))
+					return
+				}
+				acc = transformer.reduce(acc, data)
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,93 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	sum := func(acc, next stream.T) stream.T { return acc.(int) + next.(int) }
+	zero := func() stream.T { return 0 }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+
+			Convey("When I apply the transformer with a long interval", func() {
+				out <- 3
+				close(out)
+				transformer := transformers.Snapshot(sum, zero, time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the final accumulator is emitted once the stream completes", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{6})
+				})
+			})
+
+			Convey("When the interval elapses before the stream completes", func() {
+				transformer := transformers.Snapshot(sum, zero, 20*time.Millisecond)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				So(<-next, ShouldEqual, 3)
+				out <- 3
+				close(out)
+
+				Convey("Then snapshots keep accumulating without being reset", func() {
+					items := next.ReadAll()
+					So(items[len(items)-1], ShouldEqual, 6)
+				})
+			})
+
+			Convey("When I apply the transformer with a copy function", func() {
+				out <- 3
+				close(out)
+				appendItem := func(acc, next stream.T) stream.T { return append(acc.([]int), next.(int)) }
+				transformer := transformers.Snapshot(appendItem, func() stream.T { return make([]int, 0, 10) }, time.Hour).
+					CopyWith(func(acc stream.T) stream.T { return append([]int{}, acc.([]int)...) })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then copies of the accumulator are emitted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]int{1, 2, 3}})
+				})
+			})
+
+			Convey("When I apply the transformer with an invalid interval", func() {
+				close(out)
+				transformer := transformers.Snapshot(sum, zero, 0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidInterval)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				close(out)
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Snapshot(sum, zero, time.Hour)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no snapshot is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package transformers
+
+import (
+	"errors"
+	"github.com/drborges/rivers/stream"
+	"reflect"
+)
+
+var ErrNoSuchOrdered = errors.New("Sort keys must be numbers or strings of the same kind")
+
+// Sort emits the items sorted by less once upstream completes. Since no
+// item can be emitted before the last one is seen, the whole stream is
+// buffered in memory, so it is only suitable for bounded streams. Nothing
+// is emitted if the context is closed before upstream completes.
+func Sort(less stream.SortByFn) stream.Transformer {
+	items := []stream.T{}
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			items = append(items, data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			less.Sort(items)
+			for _, item := range items {
+				emitter.Emit(item)
+			}
+		},
+	}
+}
+
+// SortBy sorts items in ascending order of the key extracted by key, which
+// must be a number or a string, see Sort. Keys of mixed or other kinds
+// close the context with ErrNoSuchOrdered.
+func SortBy(key stream.MapFn) stream.Transformer {
+	return Sort(func(a, b stream.T) bool {
+		return lessKey(reflect.ValueOf(key(a)), reflect.ValueOf(key(b)))
+	})
+}
+
+func lessKey(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() || a.Kind() != b.Kind() {
+		panic(ErrNoSuchOrdered)
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	panic(ErrNoSuchOrdered)
+}
@@ -0,0 +1,68 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- "bb"
+			out <- "c"
+			out <- "aaa"
+			close(out)
+
+			Convey("When I sort the stream", func() {
+				transformer := transformers.Sort(func(a, b stream.T) bool { return a.(string) < b.(string) })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are emitted sorted", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"aaa", "bb", "c"})
+				})
+			})
+
+			Convey("When I sort the stream by key", func() {
+				transformer := transformers.SortBy(func(data stream.T) stream.T { return len(data.(string)) })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are emitted sorted by key", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"c", "bb", "aaa"})
+				})
+			})
+
+			Convey("When I sort the stream by a non ordered key", func() {
+				transformer := transformers.SortBy(func(data stream.T) stream.T { return []string{} })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrNoSuchOrdered)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I sort the stream", func() {
+					transformer := transformers.Sort(func(a, b stream.T) bool { return a.(string) < b.(string) })
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type attempt struct {
+	result    stream.T
+	err       error
+	recovered interface{}
+}
+
+type speculate struct {
+	context  stream.Context
+	fn       func(stream.T) (stream.T, error)
+	after    time.Duration
+	inflight chan struct{}
+}
+
+// Speculate maps items with fn, launching a second, speculative, call for
+// items whose first call has not returned within after and taking the
+// first successful result. The context is closed with an error only if
+// every launched call fails. At most maxInflight speculative calls run at
+// once, items past that bound wait on their first call only. Since fn
+// cannot be interrupted, losing calls are left to finish on their own and
+// their results are discarded.
+func Speculate(fn func(stream.T) (stream.T, error), after time.Duration, maxInflight int) stream.Transformer {
+	if maxInflight < 0 {
+		maxInflight = 0
+	}
+
+	return &speculate{
+		fn:       fn,
+		after:    after,
+		inflight: make(chan struct{}, maxInflight),
+	}
+}
+
+func (transformer *speculate) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *speculate) Transform(in stream.Readable) stream.Readable {
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			// Buffered so that losing calls never block once done
+			attempts := make(chan attempt, 2)
+			launch := func(release func()) {
+				go func() {
+					defer release()
+					defer func() {
+						if r := recover(); r != nil {
+							attempts <- attempt{recovered: r}
+						}
+					}()
+					result, err := transformer.fn(data)
+					attempts <- attempt{result: result, err: err}
+				}()
+			}
+
+			launch(func() {})
+			launched := 1
+			speculation := time.After(transformer.after)
+
+			var err error
+			for failed := 0; failed < launched; {
+				select {
+				case <-transformer.context.Failure():
+					return nil
+				case <-transformer.context.Done():
+					return nil
+				case <-speculation:
+					speculation = nil
+					select {
+					case transformer.inflight <- struct{}{}:
+						launch(func() { <-transformer.inflight })
+						launched++
+					default:
+					}
+				case a := <-attempts:
+					if a.recovered != nil {
+						panic(a.recovered)
+					}
+					if a.err == nil {
+						emitter.Emit(a.result)
+						return nil
+					}
+					err = a.err
+					failed++
+				}
+			}
+			return err
+		},
+	}
+
+	observer.Attach(transformer.context)
+	return observer.Transform(in)
+}
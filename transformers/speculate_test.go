@@ -0,0 +1,104 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSpeculate(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(1)
+			out <- 1
+			close(out)
+
+			Convey("When the first call hangs", func() {
+				var calls int64
+				hang := make(chan struct{})
+				defer close(hang)
+
+				fn := func(data stream.T) (stream.T, error) {
+					if atomic.AddInt64(&calls, 1) == 1 {
+						<-hang
+					}
+					return data.(int) * 10, nil
+				}
+
+				Convey("And I apply the transformer", func() {
+					transformer := transformers.Speculate(fn, 10*time.Millisecond, 1)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then the speculative call result is emitted", func() {
+						So(next.ReadAll(), ShouldResemble, []stream.T{10})
+						So(atomic.LoadInt64(&calls), ShouldEqual, 2)
+					})
+				})
+			})
+
+			Convey("When speculation is not allowed", func() {
+				var calls int64
+				fn := func(data stream.T) (stream.T, error) {
+					atomic.AddInt64(&calls, 1)
+					time.Sleep(30 * time.Millisecond)
+					return data.(int) * 10, nil
+				}
+
+				Convey("And I apply the transformer", func() {
+					transformer := transformers.Speculate(fn, time.Millisecond, 0)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then the first call result is awaited", func() {
+						So(next.ReadAll(), ShouldResemble, []stream.T{10})
+						So(atomic.LoadInt64(&calls), ShouldEqual, 1)
+					})
+				})
+			})
+
+			Convey("When every call fails", func() {
+				failure := errors.New("dependency failure")
+				fn := func(data stream.T) (stream.T, error) {
+					time.Sleep(20 * time.Millisecond)
+					return nil, failure
+				}
+
+				Convey("And I apply the transformer", func() {
+					transformer := transformers.Speculate(fn, time.Millisecond, 1)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then the context is closed with the error", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+						So(context.Err(), ShouldEqual, failure)
+					})
+				})
+			})
+
+			Convey("When a call panics", func() {
+				fn := func(data stream.T) (stream.T, error) {
+					panic("speculate failure")
+				}
+
+				Convey("And I apply the transformer", func() {
+					transformer := transformers.Speculate(fn, time.Second, 1)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then the context is closed with the recovered error", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+						So(context.Err().Error(), ShouldEqual, "Recovered from speculate failure")
+					})
+				})
+			})
+		})
+	})
+}
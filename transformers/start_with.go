@@ -0,0 +1,54 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type startWith struct {
+	context stream.Context
+	items   []stream.T
+}
+
+// StartWith emits the given items right away, before forwarding upstream
+func StartWith(items ...stream.T) stream.Transformer {
+	return &startWith{
+		items: items,
+	}
+}
+
+func (transformer *startWith) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *startWith) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer transformer.context.Recover()
+
+		for _, item := range transformer.items {
+			emitter.Emit(item)
+		}
+
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-in:
+				if !more {
+					return
+				}
+				emitter.Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
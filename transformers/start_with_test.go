@@ -0,0 +1,68 @@
+package transformers_test
+
+import (
+	"errors"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestStartWithAndEndWith(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- 1
+			out <- 2
+			close(out)
+
+			Convey("When I start the stream with some items", func() {
+				transformer := transformers.StartWith("header", "columns")
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then they are prepended to the stream", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"header", "columns", 1, 2})
+				})
+			})
+
+			Convey("When I end the stream with some items", func() {
+				transformer := transformers.EndWith("footer")
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then they are appended to the stream", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, "footer"})
+				})
+			})
+
+			Convey("When the upstream fails", func() {
+				context.Close(errors.New("upstream failure"))
+				transformer := transformers.EndWith("footer")
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no item is appended", func() {
+					So(next.ReadAll(), ShouldNotContain, "footer")
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I start the stream with some items", func() {
+					transformer := transformers.StartWith("header")
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
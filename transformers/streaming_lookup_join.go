@@ -0,0 +1,91 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+type streamingLookupJoin struct {
+	context    stream.Context
+	fetch      func(key stream.T) (stream.T, error)
+	key        stream.MapFn
+	merge      func(item, dim stream.T) stream.T
+	cache      *lruCache
+	fetches    chan struct{}
+	deadLetter stream.Writable
+}
+
+// StreamingLookupJoin merges every item with the dimension found under
+// its key, fetching dimensions on cache misses and keeping the cacheSize
+// most recently used ones. Fetches run one at a time across parallel
+// stages by default, see MaxFetches. A failing fetch closes the context
+// unless a dead letter stream is given, see DeadLetterTo.
+func StreamingLookupJoin(fetch func(key stream.T) (stream.T, error), cacheSize int, key stream.MapFn, merge func(item, dim stream.T) stream.T) *streamingLookupJoin {
+	return &streamingLookupJoin{
+		fetch:   fetch,
+		key:     key,
+		merge:   merge,
+		cache:   newLRUCache(cacheSize),
+		fetches: make(chan struct{}, 1),
+	}
+}
+
+// MaxFetches bounds the number of concurrent fetches to n
+func (join *streamingLookupJoin) MaxFetches(n int) *streamingLookupJoin {
+	if n <= 0 {
+		n = 1
+	}
+	join.fetches = make(chan struct{}, n)
+	return join
+}
+
+// DeadLetterTo sends a DeadLetter to deadLetter for every failing fetch
+// instead of closing the context. The dead letter stream is not closed by
+// the transformer and must be drained for the stream to make progress.
+func (join *streamingLookupJoin) DeadLetterTo(deadLetter stream.Writable) *streamingLookupJoin {
+	join.deadLetter = deadLetter
+	return join
+}
+
+func (join *streamingLookupJoin) Attach(context stream.Context) {
+	join.context = context
+}
+
+func (join *streamingLookupJoin) Transform(in stream.Readable) stream.Readable {
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			key := join.key(data)
+			dim, cached := join.cache.get(key)
+			if !cached {
+				select {
+				case <-join.context.Failure():
+					return nil
+				case <-join.context.Done():
+					return nil
+				case join.fetches <- struct{}{}:
+				}
+
+				var err error
+				dim, err = join.fetch(key)
+				<-join.fetches
+
+				if err != nil {
+					if join.deadLetter == nil {
+						return err
+					}
+
+					select {
+					case <-join.context.Failure():
+					case <-join.context.Done():
+					case join.deadLetter <- DeadLetter{Item: data, Err: err}:
+					}
+					return nil
+				}
+				join.cache.put(key, dim)
+			}
+
+			emitter.Emit(join.merge(data, dim))
+			return nil
+		},
+	}
+
+	observer.Attach(join.context)
+	return observer.Transform(in)
+}
@@ -0,0 +1,102 @@
+package transformers_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestStreamingLookupJoin(t *testing.T) {
+	key := func(data stream.T) stream.T { return data }
+	merge := func(item, dim stream.T) stream.T { return fmt.Sprintf("%v:%v", item, dim) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+		fetched := []stream.T{}
+		fetch := func(key stream.T) (stream.T, error) {
+			fetched = append(fetched, key)
+			return strings.ToUpper(key.(string)), nil
+		}
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(6)
+			out <- "a"
+			out <- "b"
+			out <- "a"
+			out <- "c"
+			out <- "a"
+			out <- "b"
+			close(out)
+
+			Convey("When I apply the transformer with a cache of 2 dimensions", func() {
+				transformer := transformers.StreamingLookupJoin(fetch, 2, key, merge)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are merged with their dimensions fetched on cache misses", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{"a:A", "b:B", "a:A", "c:C", "a:A", "b:B"})
+					So(fetched, ShouldResemble, []stream.T{"a", "b", "c", "b"})
+				})
+			})
+
+			Convey("When fetching a dimension fails", func() {
+				failure := errors.New("fetch failed")
+				failing := func(key stream.T) (stream.T, error) {
+					if key == "b" {
+						return nil, failure
+					}
+					return fetch(key)
+				}
+
+				Convey("And I apply the transformer", func() {
+					transformer := transformers.StreamingLookupJoin(failing, 2, key, merge)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then the context is closed with the error", func() {
+						So(next.ReadAll(), ShouldResemble, []stream.T{"a:A"})
+						So(context.Err(), ShouldEqual, failure)
+					})
+				})
+
+				Convey("And I apply the transformer with a dead letter stream", func() {
+					deadLetters, deadLetter := stream.New(2)
+					transformer := transformers.StreamingLookupJoin(failing, 2, key, merge).DeadLetterTo(deadLetter)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then failed items are sent to the dead letter stream", func() {
+						So(next.ReadAll(), ShouldResemble, []stream.T{"a:A", "a:A", "c:C", "a:A"})
+						So(context.Err(), ShouldBeNil)
+
+						close(deadLetter)
+						So(deadLetters.ReadAll(), ShouldResemble, []stream.T{
+							transformers.DeadLetter{Item: "b", Err: failure},
+							transformers.DeadLetter{Item: "b", Err: failure},
+						})
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.StreamingLookupJoin(fetch, 2, key, merge)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+						So(fetched, ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
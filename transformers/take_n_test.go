@@ -29,6 +29,41 @@ func TestTakeN(t *testing.T) {
 				})
 			})
 
+			Convey("When I take no items", func() {
+				transformer := transformers.TakeFirst(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then an empty stream is returned and upstream told to stop", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldBeNil)
+
+					_, open := <-context.Done()
+					So(open, ShouldBeFalse)
+				})
+			})
+
+			Convey("When I drop fewer items than there are", func() {
+				transformer := transformers.DropFirst(2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the remaining items are forwarded", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{3})
+				})
+			})
+
+			Convey("When I drop more items than there are", func() {
+				transformer := transformers.DropFirst(5)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then an empty stream is returned", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
 			Convey("When I close the context", func() {
 				context.Close(stream.Done)
 
@@ -43,5 +78,21 @@ func TestTakeN(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("And a stream that is not yet closed", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+
+			Convey("When I take as many items as there are", func() {
+				transformer := transformers.TakeFirst(2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the stream is closed without waiting for more items", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2})
+				})
+			})
+		})
 	})
 }
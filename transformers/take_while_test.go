@@ -0,0 +1,124 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestTakeWhile(t *testing.T) {
+	lessThan := func(n int) stream.PredicateFn {
+		return func(data stream.T) bool { return data.(int) < n }
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 1
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.TakeWhile(lessThan(3))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are forwarded until the predicate first fails", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2})
+
+					_, open := <-context.Done()
+					So(open, ShouldBeFalse)
+				})
+			})
+
+			Convey("When the predicate never matches", func() {
+				transformer := transformers.TakeWhile(lessThan(0))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no item is forwarded", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When the predicate matches every item", func() {
+				transformer := transformers.TakeWhile(lessThan(10))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then every item is forwarded", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 1})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.TakeWhile(lessThan(10))
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestDropWhile(t *testing.T) {
+	lessThan := func(n int) stream.PredicateFn {
+		return func(data stream.T) bool { return data.(int) < n }
+	}
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(4)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 1
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.DropWhile(lessThan(3))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only the leading run of matching items is dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{3, 1})
+				})
+			})
+
+			Convey("When the predicate never matches", func() {
+				transformer := transformers.DropWhile(lessThan(0))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then every item is forwarded", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1, 2, 3, 1})
+				})
+			})
+
+			Convey("When the predicate matches every item", func() {
+				transformer := transformers.DropWhile(lessThan(10))
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then no item is forwarded", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+
+			Convey("When I apply the transformer to a burst of items", func() {
+				out <- 1
+				out <- 2
+				out <- 3
+				close(out)
+				transformer := transformers.Throttle(time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only the leading item is forwarded", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{1})
+				})
+			})
+
+			Convey("When items arrive after the cooldown", func() {
+				transformer := transformers.Throttle(20 * time.Millisecond)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				out <- 1
+				out <- 2
+				So(<-next, ShouldEqual, 1)
+				time.Sleep(40 * time.Millisecond)
+				out <- 3
+				close(out)
+
+				Convey("Then the first item of each interval is forwarded", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{3})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				out <- 1
+				close(out)
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Throttle(time.Hour)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type outcome struct {
+	result    stream.T
+	recovered interface{}
+}
+
+type timeoutPerItem struct {
+	context stream.Context
+	timeout time.Duration
+	fn      stream.MapFn
+}
+
+// TimeoutPerItem maps items with fn, closing the context with
+// stream.Timeout as soon as a single call takes longer than timeout. The
+// call is abandoned rather than interrupted, its result discarded once it
+// returns.
+func TimeoutPerItem(timeout time.Duration, fn stream.MapFn) stream.Transformer {
+	return &timeoutPerItem{
+		timeout: timeout,
+		fn:      fn,
+	}
+}
+
+func (transformer *timeoutPerItem) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *timeoutPerItem) Transform(in stream.Readable) stream.Readable {
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			// Buffered so that abandoned calls never block once done
+			outcomes := make(chan outcome, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						outcomes <- outcome{recovered: r}
+					}
+				}()
+				outcomes <- outcome{result: transformer.fn(data)}
+			}()
+
+			select {
+			case <-transformer.context.Failure():
+				return nil
+			case <-transformer.context.Done():
+				return nil
+			case <-time.After(transformer.timeout):
+				return stream.Timeout
+			case o := <-outcomes:
+				if o.recovered != nil {
+					panic(o.recovered)
+				}
+				emitter.Emit(o.result)
+				return nil
+			}
+		},
+	}
+
+	observer.Attach(transformer.context)
+	return observer.Transform(in)
+}
@@ -0,0 +1,64 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestTimeoutPerItem(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+			close(out)
+
+			Convey("When every call finishes in time", func() {
+				transformer := transformers.TimeoutPerItem(time.Second, func(data stream.T) stream.T { return data.(int) * 10 })
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then every item is mapped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{10, 20, 30})
+					So(context.Err(), ShouldBeNil)
+				})
+			})
+
+			Convey("When a single call takes too long", func() {
+				transformer := transformers.TimeoutPerItem(10*time.Millisecond, func(data stream.T) stream.T {
+					if data == 2 {
+						time.Sleep(50 * time.Millisecond)
+					}
+					return data.(int) * 10
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with a timeout", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{10})
+					So(context.Err(), ShouldEqual, stream.Timeout)
+				})
+			})
+
+			Convey("When a call panics", func() {
+				transformer := transformers.TimeoutPerItem(time.Second, func(data stream.T) stream.T {
+					panic("map failure")
+				})
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with the recovered error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err().Error(), ShouldEqual, "Recovered from map failure")
+				})
+			})
+		})
+	})
+}
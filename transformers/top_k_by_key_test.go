@@ -0,0 +1,62 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestTopKByKey(t *testing.T) {
+	evensAndOdds := func(d stream.T) stream.T {
+		if d.(int)%2 == 0 {
+			return "evens"
+		}
+		return "odds"
+	}
+	lessThan := func(a, b stream.T) bool { return a.(int) < b.(int) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(7)
+			out <- 3
+			out <- 2
+			out <- 7
+			out <- 8
+			out <- 1
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.TopKByKey(evensAndOdds, 2, lessThan)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the top items of each key are emitted in first-seen key order", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.TopK{Key: "odds", Top: []stream.T{7, 5}},
+						transformers.TopK{Key: "evens", Top: []stream.T{8, 4}},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.TopKByKey(evensAndOdds, 2, lessThan)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
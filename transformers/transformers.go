@@ -1,8 +1,13 @@
 package transformers
 
 import (
+	"errors"
 	"github.com/drborges/rivers/stream"
+	"math/rand"
 	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 func Filter(fn stream.PredicateFn) stream.Transformer {
@@ -16,6 +21,10 @@ func Filter(fn stream.PredicateFn) stream.Transformer {
 	}
 }
 
+func FilterNot(fn stream.PredicateFn) stream.Transformer {
+	return Filter(func(data stream.T) bool { return !fn(data) })
+}
+
 func FindBy(fn stream.PredicateFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -28,16 +37,73 @@ func FindBy(fn stream.PredicateFn) stream.Transformer {
 	}
 }
 
+// TakeFirst forwards the first n items, stopping upstream as soon as the
+// n-th one is emitted rather than waiting for yet another item
 func TakeFirst(n int) stream.Transformer {
+	if n <= 0 {
+		return &empty{}
+	}
+
 	taken := 0
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(data)
+			taken++
 			if taken >= n {
 				return stream.Done
 			}
+			return nil
+		},
+	}
+}
+
+var ErrIndexOutOfRange = errors.New("Index out of range")
+
+// ElementAt forwards only the item at the zero based index n, stopping
+// upstream right after. Streams completing before reaching it close the
+// context with ErrIndexOutOfRange.
+func ElementAt(n int) stream.Transformer {
+	if n < 0 {
+		return &empty{err: ErrIndexOutOfRange}
+	}
+
+	index := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if index == n {
+				emitter.Emit(data)
+				return stream.Done
+			}
+			index++
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			panic(ErrIndexOutOfRange)
+		},
+	}
+}
+
+// Limit emits exactly n items even when applied to parallel stages, which
+// share its counters, stopping upstream once the n-th item is emitted.
+// Unlike TakeFirst it does not overshoot under parallelism.
+func Limit(n int) stream.Transformer {
+	if n <= 0 {
+		return &empty{}
+	}
+
+	var reserved, emitted int64
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if atomic.AddInt64(&reserved, 1) > int64(n) {
+				return nil
+			}
 
 			emitter.Emit(data)
-			taken++
+
+			// Only stop once every reserved item made it downstream
+			if atomic.AddInt64(&emitted, 1) == int64(n) {
+				return stream.Done
+			}
 			return nil
 		},
 	}
@@ -58,6 +124,36 @@ func DropFirst(n int) stream.Transformer {
 	}
 }
 
+// TakeWhile forwards items until fn first fails, stopping upstream then
+func TakeWhile(fn stream.PredicateFn) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if !fn(data) {
+				return stream.Done
+			}
+
+			emitter.Emit(data)
+			return nil
+		},
+	}
+}
+
+// DropWhile skips items until fn first fails, forwarding every item from then on
+func DropWhile(fn stream.PredicateFn) stream.Transformer {
+	dropping := true
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if dropping && fn(data) {
+				return nil
+			}
+
+			dropping = false
+			emitter.Emit(data)
+			return nil
+		},
+	}
+}
+
 func Map(fn stream.MapFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -88,26 +184,138 @@ func Reduce(acc stream.T, fn stream.ReduceFn) stream.Transformer {
 	}
 }
 
+func Scan(acc stream.T, fn stream.ReduceFn) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			acc = fn(acc, data)
+			emitter.Emit(acc)
+			return nil
+		},
+	}
+}
+
+// ReverseBuffered keeps every item in memory until the stream completes,
+// emitting them in reverse order, and so is only meant for bounded streams
+func ReverseBuffered() stream.Transformer {
+	items := []stream.T{}
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			items = append(items, data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			for i := len(items) - 1; i >= 0; i-- {
+				emitter.Emit(items[i])
+			}
+		},
+	}
+}
+
+// Flatten emits the elements of slice items individually, flattening
+// nested slices one level only, and forwards other items as they are
 func Flatten() stream.Transformer {
+	return flatten(false)
+}
+
+var ErrNoSuchSlice = errors.New("Element is not a slice")
+
+// FlattenStrict flattens slice items like Flatten, closing the context
+// with ErrNoSuchSlice on any other item
+func FlattenStrict() stream.Transformer {
+	return flatten(true)
+}
+
+func flatten(strict bool) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
 			dv := reflect.ValueOf(data)
-			if dv.Kind() == reflect.Slice || dv.Kind() == reflect.Ptr && dv.Elem().Kind() == reflect.Slice {
-				for i := 0; i < dv.Len(); i++ {
-					emitter.Emit(dv.Index(i).Interface())
+			if dv.Kind() == reflect.Ptr && dv.Elem().Kind() == reflect.Slice {
+				dv = dv.Elem()
+			}
+
+			if dv.Kind() != reflect.Slice {
+				if strict {
+					return ErrNoSuchSlice
 				}
-			} else {
 				emitter.Emit(data)
+				return nil
+			}
+
+			for i := 0; i < dv.Len(); i++ {
+				emitter.Emit(dv.Index(i).Interface())
 			}
 			return nil
 		},
 	}
 }
 
+var ErrInvalidBatchSize = errors.New("Batch size must be greater than zero")
+
+// Batch emits upstream items in slices of up to size items, flushing the
+// last partial batch once upstream completes. A partial batch is discarded
+// if the context is closed, and a size not greater than zero closes the
+// context with ErrInvalidBatchSize.
 func Batch(size int) stream.Transformer {
+	if size <= 0 {
+		return &empty{err: ErrInvalidBatchSize}
+	}
 	return BatchBy(&batch{size: size})
 }
 
+// Pairwise emits every item paired with the one before it as a [2]stream.T,
+// so the first item alone emits nothing
+func Pairwise() stream.Transformer {
+	var previous stream.T
+	started := false
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if started {
+				emitter.Emit([2]stream.T{previous, data})
+			}
+			started = true
+			previous = data
+			return nil
+		},
+	}
+}
+
+var ErrInvalidWindow = errors.New("Window size and step must be greater than zero")
+
+// WindowTumbling emits consecutive, non overlapping windows of size items,
+// flushing the last partial window once upstream completes
+func WindowTumbling(size int) stream.Transformer {
+	if size <= 0 {
+		return &empty{err: ErrInvalidWindow}
+	}
+	return BatchBy(&batch{size: size})
+}
+
+// WindowSliding emits windows of size items starting every step items, so
+// windows overlap when step is smaller than size and skip items when it is
+// larger. Partial windows are dropped once upstream completes.
+func WindowSliding(size, step int) stream.Transformer {
+	if size <= 0 || step <= 0 {
+		return &empty{err: ErrInvalidWindow}
+	}
+
+	var window []stream.T
+	seen := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			window = append(window, data)
+			if len(window) > size {
+				window = window[1:]
+			}
+
+			seen++
+			if seen >= size && (seen-size)%step == 0 {
+				emitter.Emit(append([]stream.T{}, window...))
+			}
+			return nil
+		},
+	}
+}
+
 func BatchBy(batch stream.Batch) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
@@ -125,6 +333,437 @@ func BatchBy(batch stream.Batch) stream.Transformer {
 	}
 }
 
+func BatchBytes(maxBytes int, size func(stream.T) int) stream.Transformer {
+	var items []stream.T
+	total := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			n := size(data)
+			if len(items) > 0 && total+n > maxBytes {
+				emitter.Emit(items)
+				items = nil
+				total = 0
+			}
+			items = append(items, data)
+			total += n
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if len(items) > 0 {
+				emitter.Emit(items)
+			}
+		},
+	}
+}
+
+type TopK struct {
+	Key stream.T
+	Top []stream.T
+}
+
+func TopKByKey(key func(stream.T) stream.T, k int, less func(a, b stream.T) bool) stream.Transformer {
+	var keys []stream.T
+	heaps := make(map[stream.T]*boundedHeap)
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			groupKey := key(data)
+			h, exists := heaps[groupKey]
+			if !exists {
+				h = &boundedHeap{size: k, less: less}
+				heaps[groupKey] = h
+				keys = append(keys, groupKey)
+			}
+			h.Offer(data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			for _, groupKey := range keys {
+				emitter.Emit(TopK{Key: groupKey, Top: heaps[groupKey].Top()})
+			}
+		},
+	}
+}
+
+var ErrNoSuchGroup = errors.New("Element is not a group")
+
+type Group struct {
+	Key   stream.T
+	Items []stream.T
+}
+
+type KeyedItem struct {
+	Key  stream.T
+	Item stream.T
+}
+
+// UngroupWithKey explodes Group items back into one KeyedItem per
+// element, closing the context with ErrNoSuchGroup on any other item
+func UngroupWithKey() stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			group, ok := data.(Group)
+			if !ok {
+				return ErrNoSuchGroup
+			}
+
+			for _, item := range group.Items {
+				emitter.Emit(KeyedItem{Key: group.Key, Item: item})
+			}
+			return nil
+		},
+	}
+}
+
+type LSHBucketed struct {
+	Bucket uint64
+	Item   stream.T
+}
+
+// LSHBucket splits the 64 bits locality sensitive signature computed by hash
+// into bands, emitting the item once per band tagged with that band's
+// bucket, so items agreeing on any band share a bucket downstream
+func LSHBucket(hash func(stream.T) uint64, bands int) stream.Transformer {
+	if bands <= 0 || bands > 64 {
+		bands = 1
+	}
+
+	width := uint(64 / bands)
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			signature := hash(data)
+			for band := 0; band < bands; band++ {
+				value := signature >> (uint(band) * width)
+				if width < 64 {
+					value &= 1<<width - 1
+				}
+
+				// the band index lives in the top bits so that equal
+				// values from different bands do not share a bucket
+				emitter.Emit(LSHBucketed{Bucket: uint64(band)<<58 | value, Item: data})
+			}
+			return nil
+		},
+	}
+}
+
+// CoalesceRanges merges overlapping or adjacent ranges, built back into
+// items by build. It assumes the stream is sorted by the ranges' start.
+func CoalesceRanges(start, end func(stream.T) int64, build func(start, end int64) stream.T) stream.Transformer {
+	var from, to int64
+	pending := false
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			s, e := start(data), end(data)
+			if pending && s <= to+1 {
+				if e > to {
+					to = e
+				}
+				return nil
+			}
+
+			if pending {
+				emitter.Emit(build(from, to))
+			}
+			from, to, pending = s, e, true
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if pending {
+				emitter.Emit(build(from, to))
+			}
+		},
+	}
+}
+
+var ErrNoPatterns = errors.New("MatchSequence requires at least one pattern")
+
+// MatchSequence emits onMatch's result for every run of consecutive items
+// satisfying patterns in order. Runs do not overlap, and a broken partial
+// match is retried from its later items so only len(patterns) items are
+// ever kept in memory. No patterns closes the context with ErrNoPatterns.
+func MatchSequence(patterns []stream.PredicateFn, onMatch func([]stream.T) stream.T) stream.Transformer {
+	if len(patterns) == 0 {
+		return &empty{err: ErrNoPatterns}
+	}
+
+	var window []stream.T
+	matches := func(window []stream.T) bool {
+		for i, data := range window {
+			if !patterns[i](data) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			window = append(window, data)
+			for len(window) > 0 && !matches(window) {
+				window = window[1:]
+			}
+
+			if len(window) > 0 && len(window) == len(patterns) {
+				emitter.Emit(onMatch(window))
+				window = nil
+			}
+			return nil
+		},
+	}
+}
+
+type HysteresisState struct {
+	On   bool
+	Data stream.T
+}
+
+func Hysteresis(on stream.PredicateFn, offAfter int) stream.Transformer {
+	state := false
+	offCount := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if on(data) {
+				offCount = 0
+				if !state {
+					state = true
+					emitter.Emit(HysteresisState{On: true, Data: data})
+				}
+				return nil
+			}
+
+			if !state {
+				return nil
+			}
+
+			offCount++
+			if offCount >= offAfter {
+				state = false
+				offCount = 0
+				emitter.Emit(HysteresisState{On: false, Data: data})
+			}
+			return nil
+		},
+	}
+}
+
+func AppendOnComplete(sentinel func() stream.T) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			emitter.Emit(sentinel())
+		},
+	}
+}
+
+// EndWith appends the given items once upstream completes gracefully
+func EndWith(items ...stream.T) stream.Transformer {
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			for _, item := range items {
+				emitter.Emit(item)
+			}
+		},
+	}
+}
+
+// DefaultIfEmpty emits value if upstream completes gracefully without
+// items, forwarding upstream unchanged otherwise
+func DefaultIfEmpty(value stream.T) stream.Transformer {
+	empty := true
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			empty = false
+			emitter.Emit(data)
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if empty {
+				emitter.Emit(value)
+			}
+		},
+	}
+}
+
+// Around calls onFirst with the first item and onLast with the last one
+// once the stream completes. Neither is called on empty streams and onLast
+// is skipped if the stream fails.
+func Around(onFirst, onLast stream.EachFn) stream.Transformer {
+	var last stream.T
+	seen := false
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			if !seen {
+				seen = true
+				onFirst(data)
+			}
+
+			emitter.Emit(data)
+			last = data
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if seen {
+				onLast(last)
+			}
+		},
+	}
+}
+
+func SampleProbability(p float64, rng *rand.Rand) stream.Transformer {
+	var mutex sync.Mutex
+	return Filter(func(data stream.T) bool {
+		if p <= 0 {
+			return false
+		}
+
+		if p >= 1 {
+			return true
+		}
+
+		// parallel stages share this transformer, and so the rng
+		mutex.Lock()
+		defer mutex.Unlock()
+		return rng.Float64() < p
+	})
+}
+
+// Throttle forwards at most one item per interval, on the leading edge:
+// the first item is forwarded right away and those arriving within the
+// following interval are dropped
+func Throttle(interval time.Duration) stream.Transformer {
+	var mutex sync.Mutex
+	var last time.Time
+	return Filter(func(data stream.T) bool {
+		// parallel stages share this transformer, and so the cooldown
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		now := time.Now()
+		if !last.IsZero() && now.Sub(last) < interval {
+			return false
+		}
+		last = now
+		return true
+	})
+}
+
+// Progress reports the running completion percentage of a stream whose
+// total size is known upfront, clamping at 100% if more items arrive
+func Progress(total int, report func(percent float64)) stream.Transformer {
+	count := 0
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(data)
+			count++
+
+			percent := 100.0
+			if count < total {
+				percent = float64(count) * 100 / float64(total)
+			}
+			report(percent)
+			return nil
+		},
+	}
+}
+
+// BloomDedup drops probable duplicates using a Bloom filter sized for
+// expectedN keys, trading exactness for constant memory: roughly a
+// falsePositiveRate share of unique items is dropped as well, more so
+// once more than expectedN keys have been seen.
+func BloomDedup(expectedN int, falsePositiveRate float64, key func(stream.T) string) stream.Transformer {
+	var mutex sync.Mutex
+	filter := newBloomFilter(expectedN, falsePositiveRate)
+	return Filter(func(data stream.T) bool {
+		// parallel stages share this transformer, and so the filter
+		mutex.Lock()
+		defer mutex.Unlock()
+		return !filter.add(key(data))
+	})
+}
+
+var ErrNoSuchComparable = errors.New("Element is not comparable")
+
+// Distinct forwards only the first occurrence of each item, keeping every
+// distinct item seen in memory. Items must be comparable: slices, maps and
+// functions close the context with ErrNoSuchComparable.
+func Distinct() stream.Transformer {
+	return DistinctBy(func(data stream.T) stream.T { return data })
+}
+
+// DistinctBy forwards only the first item of each distinct key, see Distinct
+func DistinctBy(key func(stream.T) stream.T) stream.Transformer {
+	var mutex sync.Mutex
+	seen := make(map[stream.T]struct{})
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			k := key(data)
+			if k != nil && !reflect.TypeOf(k).Comparable() {
+				return ErrNoSuchComparable
+			}
+
+			// parallel stages share this transformer, and so the seen keys
+			mutex.Lock()
+			_, duplicate := seen[k]
+			seen[k] = struct{}{}
+			mutex.Unlock()
+
+			if !duplicate {
+				emitter.Emit(data)
+			}
+			return nil
+		},
+	}
+}
+
+// DedupConsecutive drops items equal to the one right before them, so
+// unlike Distinct only the previous item is kept in memory. Items must be
+// comparable, otherwise the context is closed with ErrNoSuchComparable.
+func DedupConsecutive() stream.Transformer {
+	return DedupConsecutiveBy(func(data stream.T) stream.T { return data })
+}
+
+// Compact is an alias of DedupConsecutive
+func Compact() stream.Transformer {
+	return DedupConsecutive()
+}
+
+// DedupConsecutiveBy drops items whose key equals the previous item's key,
+// see DedupConsecutive
+func DedupConsecutiveBy(key func(stream.T) stream.T) stream.Transformer {
+	var last stream.T
+	started := false
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			k := key(data)
+			if k != nil && !reflect.TypeOf(k).Comparable() {
+				return ErrNoSuchComparable
+			}
+
+			if started && k == last {
+				return nil
+			}
+
+			started = true
+			last = k
+			emitter.Emit(data)
+			return nil
+		},
+	}
+}
+
+// Tap runs fn on every item for its side effects, such as logging or
+// metrics, forwarding items unchanged. It is an alias of Each.
+func Tap(fn stream.EachFn) stream.Transformer {
+	return Each(fn)
+}
+
 func Each(fn stream.EachFn) stream.Transformer {
 	return &Observer{
 		OnNext: func(data stream.T, emitter stream.Emitter) error {
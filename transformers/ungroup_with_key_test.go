@@ -0,0 +1,67 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestUngroupWithKey(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of groups", func() {
+			in, out := stream.New(2)
+			out <- transformers.Group{Key: "odd", Items: []stream.T{1, 3}}
+			out <- transformers.Group{Key: "even", Items: []stream.T{2}}
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.UngroupWithKey()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then each item is emitted along with its group key", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.KeyedItem{Key: "odd", Item: 1},
+						transformers.KeyedItem{Key: "odd", Item: 3},
+						transformers.KeyedItem{Key: "even", Item: 2},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.UngroupWithKey()
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+
+		Convey("And a stream of items that are not groups", func() {
+			in, out := stream.New(1)
+			out <- 1
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.UngroupWithKey()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with a type error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrNoSuchGroup)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+// UploadChunk groups items of a chunked upload. Offset is the number of
+// bytes in previous chunks, and Last tells whether it ends the upload.
+type UploadChunk struct {
+	Items  []stream.T
+	Offset int
+	Bytes  int
+	Last   bool
+}
+
+// UploadChunks groups items into chunks of up to chunkBytes, as measured
+// by size, for chunked or resumable upload sinks. A single item larger
+// than chunkBytes makes a chunk on its own. A chunk is emitted once the
+// next item does not fit in it, so the one buffered when upstream
+// completes is flagged Last, and is discarded if the context is closed.
+func UploadChunks(chunkBytes int, size func(stream.T) int) stream.Transformer {
+	current := UploadChunk{}
+
+	return &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			n := size(data)
+			if len(current.Items) > 0 && current.Bytes+n > chunkBytes {
+				emitter.Emit(current)
+				current = UploadChunk{Offset: current.Offset + current.Bytes}
+			}
+			current.Items = append(current.Items, data)
+			current.Bytes += n
+			return nil
+		},
+		OnCompleted: func(emitter stream.Emitter) {
+			if len(current.Items) > 0 {
+				current.Last = true
+				emitter.Emit(current)
+			}
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestUploadChunks(t *testing.T) {
+	size := func(data stream.T) int { return len(data.([]byte)) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of byte slices", func() {
+			in, out := stream.New(4)
+			out <- []byte("abc")
+			out <- []byte("de")
+			out <- []byte("fghij")
+			out <- []byte("k")
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.UploadChunks(5, size)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are chunked and only the final chunk is flagged last", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.UploadChunk{Items: []stream.T{[]byte("abc"), []byte("de")}, Offset: 0, Bytes: 5},
+						transformers.UploadChunk{Items: []stream.T{[]byte("fghij")}, Offset: 5, Bytes: 5},
+						transformers.UploadChunk{Items: []stream.T{[]byte("k")}, Offset: 10, Bytes: 1, Last: true},
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.UploadChunks(5, size)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no chunk is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+// WatermarkMark is interleaved with data items telling downstream stages
+// that no more items older than Time are expected
+type WatermarkMark struct {
+	Time time.Time
+}
+
+type watermark struct {
+	context     stream.Context
+	timestamp   func(stream.T) time.Time
+	maxLateness time.Duration
+	late        stream.Writable
+}
+
+// Watermark tracks the maximum event time seen so far, emitting a new
+// WatermarkMark whenever maxSeen - maxLateness advances. Items older than
+// the current watermark are dropped, see LateTo for keeping them.
+func Watermark(timestamp func(stream.T) time.Time, maxLateness time.Duration) *watermark {
+	return &watermark{
+		timestamp:   timestamp,
+		maxLateness: maxLateness,
+	}
+}
+
+// LateTo sends items older than the current watermark to the given side
+// output instead of dropping them. The side output is not closed by the
+// transformer and must be drained for the stream to make progress.
+func (watermark *watermark) LateTo(late stream.Writable) stream.Transformer {
+	watermark.late = late
+	return watermark
+}
+
+func (watermark *watermark) Attach(context stream.Context) {
+	watermark.context = context
+}
+
+func (watermark *watermark) Transform(in stream.Readable) stream.Readable {
+	var maxSeen, current time.Time
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			ts := watermark.timestamp(data)
+			if ts.Before(current) {
+				if watermark.late != nil {
+					select {
+					case <-watermark.context.Failure():
+					case <-watermark.context.Done():
+					case watermark.late <- data:
+					}
+				}
+				return nil
+			}
+
+			emitter.Emit(data)
+
+			if ts.After(maxSeen) {
+				maxSeen = ts
+			}
+
+			if next := maxSeen.Add(-watermark.maxLateness); next.After(current) {
+				current = next
+				emitter.Emit(WatermarkMark{Time: current})
+			}
+			return nil
+		},
+	}
+
+	observer.Attach(watermark.context)
+	return observer.Transform(in)
+}
@@ -0,0 +1,75 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestWatermark(t *testing.T) {
+	timestamp := func(d stream.T) time.Time { return time.Unix(int64(d.(int)), 0) }
+	mark := func(sec int64) stream.T { return transformers.WatermarkMark{Time: time.Unix(sec, 0)} }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of out of order events", func() {
+			in, out := stream.New(5)
+			out <- 10
+			out <- 12
+			out <- 9
+			out <- 7
+			out <- 13
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.Watermark(timestamp, 2*time.Second)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then watermarks are interleaved with data and late items dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						10, mark(8),
+						12, mark(10),
+						13, mark(11),
+					})
+				})
+			})
+
+			Convey("When I route late items to a side output", func() {
+				late, side := stream.New(2)
+				transformer := transformers.Watermark(timestamp, 2*time.Second).LateTo(side)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then late items are sent to the side output", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						10, mark(8),
+						12, mark(10),
+						13, mark(11),
+					})
+
+					close(side)
+					So(late.ReadAll(), ShouldResemble, []stream.T{9, 7})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.Watermark(timestamp, 2*time.Second)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
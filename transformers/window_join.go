@@ -0,0 +1,124 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type joinEntry struct {
+	item stream.T
+	key  stream.T
+	ts   time.Time
+}
+
+type windowJoin struct {
+	context  stream.Context
+	right    stream.Readable
+	leftKey  stream.MapFn
+	rightKey stream.MapFn
+	leftTs   func(stream.T) time.Time
+	rightTs  func(stream.T) time.Time
+	window   time.Duration
+	merge    func(l, r stream.T) stream.T
+}
+
+// WindowJoin joins the transformed stream, the left one, with right,
+// emitting merge(l, r) for every pair of items with equal keys whose
+// timestamps are at most window apart. Timestamps are expected to grow
+// on each side, so items older than window from the other side's latest
+// timestamp can no longer match and are evicted, keeping memory bounded as
+// long as both sides progress together. The joined stream closes once both
+// sides are done.
+func WindowJoin(right stream.Readable, leftKey, rightKey stream.MapFn, leftTs, rightTs func(stream.T) time.Time, window time.Duration, merge func(l, r stream.T) stream.T) stream.Transformer {
+	return &windowJoin{
+		right:    right,
+		leftKey:  leftKey,
+		rightKey: rightKey,
+		leftTs:   leftTs,
+		rightTs:  rightTs,
+		window:   window,
+		merge:    merge,
+	}
+}
+
+func (join *windowJoin) Attach(context stream.Context) {
+	join.context = context
+}
+
+func (join *windowJoin) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(join.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer join.context.Recover()
+
+		var lefts, rights []joinEntry
+
+		evict := func(entries []joinEntry, latest time.Time) []joinEntry {
+			kept := entries[:0]
+			for _, entry := range entries {
+				if latest.Sub(entry.ts) <= join.window {
+					kept = append(kept, entry)
+				}
+			}
+			return kept
+		}
+
+		within := func(a, b time.Time) bool {
+			d := a.Sub(b)
+			return d <= join.window && d >= -join.window
+		}
+
+		left, right := in, join.right
+		for left != nil || right != nil {
+			select {
+			case <-join.context.Failure():
+				return
+			case <-join.context.Done():
+				return
+			case <-time.After(join.context.Deadline()):
+				panic(stream.Timeout)
+			case data, more := <-left:
+				if !more {
+					// rights are only buffered to match upcoming lefts
+					left = nil
+					rights = nil
+					continue
+				}
+
+				entry := joinEntry{item: data, key: join.leftKey(data), ts: join.leftTs(data)}
+				rights = evict(rights, entry.ts)
+
+				for _, r := range rights {
+					if r.key == entry.key && within(entry.ts, r.ts) {
+						emitter.Emit(join.merge(data, r.item))
+					}
+				}
+				if right != nil {
+					lefts = append(lefts, entry)
+				}
+			case data, more := <-right:
+				if !more {
+					right = nil
+					lefts = nil
+					continue
+				}
+
+				entry := joinEntry{item: data, key: join.rightKey(data), ts: join.rightTs(data)}
+				lefts = evict(lefts, entry.ts)
+
+				for _, l := range lefts {
+					if l.key == entry.key && within(l.ts, entry.ts) {
+						emitter.Emit(join.merge(l.item, data))
+					}
+				}
+				if left != nil {
+					rights = append(rights, entry)
+				}
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,66 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+type timedEvent struct {
+	Key string
+	At  int64
+}
+
+func TestWindowJoin(t *testing.T) {
+	key := func(data stream.T) stream.T { return data.(timedEvent).Key }
+	ts := func(data stream.T) time.Time { return time.Unix(data.(timedEvent).At, 0) }
+	merge := func(l, r stream.T) stream.T { return []stream.T{l, r} }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a left and a right stream of timed events", func() {
+			in, out := stream.New(3)
+			out <- timedEvent{"a", 0}
+			out <- timedEvent{"b", 10}
+			out <- timedEvent{"a", 100}
+			close(out)
+
+			right, rightOut := stream.New(3)
+			rightOut <- timedEvent{"a", 3}
+			rightOut <- timedEvent{"b", 30}
+			rightOut <- timedEvent{"a", 102}
+			close(rightOut)
+
+			Convey("When I join them within a window", func() {
+				transformer := transformers.WindowJoin(right, key, key, ts, ts, 5*time.Second, merge)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only items with equal keys within the window are joined", func() {
+					joined := next.ReadAll()
+					So(joined, ShouldHaveLength, 2)
+					So(joined, ShouldContain, []stream.T{timedEvent{"a", 0}, timedEvent{"a", 3}})
+					So(joined, ShouldContain, []stream.T{timedEvent{"a", 100}, timedEvent{"a", 102}})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.WindowJoin(right, key, key, ts, ts, 5*time.Second, merge)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"time"
+)
+
+type Window struct {
+	ID    int64
+	Items stream.Readable
+}
+
+type windowStreams struct {
+	context stream.Context
+	window  time.Duration
+}
+
+// WindowStreams splits the stream into one sub-stream per time window,
+// emitting a Window as soon as its first item arrives. Windows are based
+// on processing time, identified by the number of windows elapsed since
+// the unix epoch, and their sub-streams close as the window ends. Items not
+// yet read from a sub-stream are queued in memory, so a sub-stream that is
+// never read does not block the others. A window not greater than zero
+// closes the context with ErrInvalidWindow.
+func WindowStreams(window time.Duration) stream.Transformer {
+	if window <= 0 {
+		return &empty{err: ErrInvalidWindow}
+	}
+
+	return &windowStreams{
+		window: window,
+	}
+}
+
+func (transformer *windowStreams) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *windowStreams) Transform(in stream.Readable) stream.Readable {
+	readable, writable := stream.New(in.Capacity())
+	emitter := stream.NewEmitter(transformer.context, writable)
+
+	go func() {
+		defer close(writable)
+		defer transformer.context.Recover()
+
+		var current chan stream.T
+		var items stream.Emitter
+		var ends <-chan time.Time
+		var id int64
+
+		closeWindow := func() {
+			if current != nil {
+				close(current)
+				current = nil
+				ends = nil
+			}
+		}
+		defer closeWindow()
+
+		for {
+			select {
+			case <-transformer.context.Failure():
+				return
+			case <-transformer.context.Done():
+				return
+			case <-time.After(transformer.context.Deadline()):
+				panic(stream.Timeout)
+			case <-ends:
+				closeWindow()
+			case data, more := <-in:
+				if !more {
+					return
+				}
+
+				now := time.Now()
+				if current != nil && now.UnixNano()/int64(transformer.window) != id {
+					closeWindow()
+				}
+
+				if current == nil {
+					current = make(chan stream.T)
+					sub := queue(transformer.context, current, in.Capacity())
+					items = stream.NewEmitter(transformer.context, current)
+					id = now.UnixNano() / int64(transformer.window)
+					ends = time.After(time.Unix(0, (id+1)*int64(transformer.window)).Sub(now))
+					emitter.Emit(Window{ID: id, Items: sub})
+				}
+
+				items.Emit(data)
+			}
+		}
+	}()
+
+	return readable
+}
@@ -0,0 +1,105 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestWindowStreams(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(3)
+			out <- 1
+			out <- 2
+			out <- 3
+
+			Convey("When I apply the transformer to the stream", func() {
+				close(out)
+				transformer := transformers.WindowStreams(time.Hour)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items within the same window are delivered by the same sub-stream", func() {
+					window := (<-next).(transformers.Window)
+					So(window.ID, ShouldEqual, time.Now().UnixNano()/int64(time.Hour))
+					So(window.Items.ReadAll(), ShouldResemble, []stream.T{1, 2, 3})
+					So(next.ReadAll(), ShouldBeEmpty)
+				})
+			})
+
+			Convey("When items arrive after the window ends", func() {
+				transformer := transformers.WindowStreams(50 * time.Millisecond)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				first := (<-next).(transformers.Window)
+				items := first.Items.ReadAll()
+				out <- 4
+				close(out)
+
+				Convey("Then the window's sub-stream is closed and a new window emitted", func() {
+					So(items, ShouldResemble, []stream.T{1, 2, 3})
+
+					second := (<-next).(transformers.Window)
+					So(second.ID, ShouldBeGreaterThan, first.ID)
+					So(second.Items.ReadAll(), ShouldResemble, []stream.T{4})
+				})
+			})
+
+			Convey("When a window's sub-stream is never read", func() {
+				transformer := transformers.WindowStreams(50 * time.Millisecond)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				// the unread window gets more items than its stream capacity
+				<-next
+				out <- 4
+				out <- 5
+				time.Sleep(60 * time.Millisecond)
+				out <- 6
+				close(out)
+
+				Convey("Then the following windows are still delivered", func() {
+					items := []stream.T{}
+					for data := range next {
+						items = append(items, data.(transformers.Window).Items.ReadAll()...)
+					}
+					So(items, ShouldContain, 6)
+				})
+			})
+
+			Convey("When I apply the transformer with an invalid window", func() {
+				close(out)
+				transformer := transformers.WindowStreams(0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidWindow)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				close(out)
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.WindowStreams(time.Hour)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no window is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
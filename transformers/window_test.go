@@ -0,0 +1,77 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(5)
+			out <- 1
+			out <- 2
+			out <- 3
+			out <- 4
+			out <- 5
+			close(out)
+
+			Convey("When I apply a tumbling window", func() {
+				transformer := transformers.WindowTumbling(2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the last partial window is flushed", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{1, 2}, []stream.T{3, 4}, []stream.T{5}})
+				})
+			})
+
+			Convey("When I apply an overlapping sliding window", func() {
+				transformer := transformers.WindowSliding(3, 1)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then a window is emitted per step", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{1, 2, 3}, []stream.T{2, 3, 4}, []stream.T{3, 4, 5}})
+				})
+			})
+
+			Convey("When I apply a sliding window with a step larger than one", func() {
+				transformer := transformers.WindowSliding(2, 2)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the last partial window is dropped", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{[]stream.T{1, 2}, []stream.T{3, 4}})
+				})
+			})
+
+			Convey("When I apply a sliding window with an invalid step", func() {
+				transformer := transformers.WindowSliding(2, 0)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidWindow)
+				})
+			})
+
+			Convey("When I apply a tumbling window with an invalid size", func() {
+				transformer := transformers.WindowTumbling(-1)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the context is closed with an error", func() {
+					So(next.ReadAll(), ShouldBeEmpty)
+					So(context.Err(), ShouldEqual, transformers.ErrInvalidWindow)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package transformers
+
+import (
+	"github.com/drborges/rivers/stream"
+	"math"
+)
+
+type Anomaly struct {
+	Data  stream.T
+	Score float64
+}
+
+type zScoreFilter struct {
+	context   stream.Context
+	value     func(stream.T) float64
+	threshold float64
+	window    []float64
+	tag       bool
+}
+
+// ZScoreFilter forwards only items whose value deviates from the mean of
+// the previous window values by more than threshold standard deviations.
+// Nothing is forwarded until the window is filled.
+func ZScoreFilter(value func(stream.T) float64, threshold float64, window int) *zScoreFilter {
+	if window <= 0 {
+		window = 1
+	}
+
+	return &zScoreFilter{
+		value:     value,
+		threshold: threshold,
+		window:    make([]float64, window),
+	}
+}
+
+// Tagged wraps forwarded items in an Anomaly along with their z-score
+func (filter *zScoreFilter) Tagged() stream.Transformer {
+	filter.tag = true
+	return filter
+}
+
+func (filter *zScoreFilter) Attach(context stream.Context) {
+	filter.context = context
+}
+
+func (filter *zScoreFilter) Transform(in stream.Readable) stream.Readable {
+	var sum, squares float64
+	seen := 0
+	size := float64(len(filter.window))
+
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			x := filter.value(data)
+			slot := seen % len(filter.window)
+
+			if seen >= len(filter.window) {
+				mean := sum / size
+				stddev := math.Sqrt(math.Max(0, squares/size-mean*mean))
+
+				score := math.Inf(1)
+				if stddev > 0 {
+					score = math.Abs(x-mean) / stddev
+				} else if x == mean {
+					score = 0
+				}
+
+				if score > filter.threshold {
+					if filter.tag {
+						emitter.Emit(Anomaly{Data: data, Score: score})
+					} else {
+						emitter.Emit(data)
+					}
+				}
+
+				oldest := filter.window[slot]
+				sum -= oldest
+				squares -= oldest * oldest
+			}
+
+			filter.window[slot] = x
+			sum += x
+			squares += x * x
+			seen++
+			return nil
+		},
+	}
+
+	observer.Attach(filter.context)
+	return observer.Transform(in)
+}
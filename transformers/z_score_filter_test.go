@@ -0,0 +1,66 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestZScoreFilter(t *testing.T) {
+	value := func(data stream.T) float64 { return float64(data.(int)) }
+
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of measurements with a spike", func() {
+			in, out := stream.New(7)
+			out <- 10
+			out <- 12
+			out <- 10
+			out <- 12
+			out <- 11
+			out <- 20
+			out <- 11
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.ZScoreFilter(value, 3, 4)
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then only the anomaly is forwarded once the window is filled", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{20})
+				})
+			})
+
+			Convey("When I apply the tagging transformer to the stream", func() {
+				transformer := transformers.ZScoreFilter(value, 3, 4).Tagged()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then the anomaly is tagged with its z-score", func() {
+					anomalies := next.ReadAll()
+					So(len(anomalies), ShouldEqual, 1)
+					So(anomalies[0].(transformers.Anomaly).Data, ShouldEqual, 20)
+					So(anomalies[0].(transformers.Anomaly).Score, ShouldAlmostEqual, 10.55, 0.01)
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.ZScoreFilter(value, 3, 4)
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package transformers
+
+import "github.com/drborges/rivers/stream"
+
+type Indexed struct {
+	Index int
+	Value stream.T
+}
+
+type zipWithIndex struct {
+	context stream.Context
+}
+
+// ZipWithIndex wraps every item in an Indexed holding its position in the
+// stream, starting at zero for every stream transformed
+func ZipWithIndex() stream.Transformer {
+	return &zipWithIndex{}
+}
+
+func (transformer *zipWithIndex) Attach(context stream.Context) {
+	transformer.context = context
+}
+
+func (transformer *zipWithIndex) Transform(in stream.Readable) stream.Readable {
+	index := 0
+	observer := &Observer{
+		OnNext: func(data stream.T, emitter stream.Emitter) error {
+			emitter.Emit(Indexed{Index: index, Value: data})
+			index++
+			return nil
+		},
+	}
+
+	observer.Attach(transformer.context)
+	return observer.Transform(in)
+}
@@ -0,0 +1,60 @@
+package transformers_test
+
+import (
+	"github.com/drborges/rivers"
+	"github.com/drborges/rivers/stream"
+	"github.com/drborges/rivers/transformers"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestZipWithIndex(t *testing.T) {
+	Convey("Given I have a context", t, func() {
+		context := rivers.NewContext()
+
+		Convey("And a stream of data", func() {
+			in, out := stream.New(2)
+			out <- "a"
+			out <- "b"
+			close(out)
+
+			Convey("When I apply the transformer to the stream", func() {
+				transformer := transformers.ZipWithIndex()
+				transformer.Attach(context)
+				next := transformer.Transform(in)
+
+				Convey("Then items are wrapped with their index", func() {
+					So(next.ReadAll(), ShouldResemble, []stream.T{
+						transformers.Indexed{Index: 0, Value: "a"},
+						transformers.Indexed{Index: 1, Value: "b"},
+					})
+				})
+
+				Convey("And I transform another stream", func() {
+					next.ReadAll()
+					other, otherOut := stream.New(1)
+					otherOut <- "c"
+					close(otherOut)
+
+					Convey("Then the index starts over", func() {
+						So(transformer.Transform(other).ReadAll(), ShouldResemble, []stream.T{transformers.Indexed{Index: 0, Value: "c"}})
+					})
+				})
+			})
+
+			Convey("When I close the context", func() {
+				context.Close(stream.Done)
+
+				Convey("And I apply the transformer to the stream", func() {
+					transformer := transformers.ZipWithIndex()
+					transformer.Attach(context)
+					next := transformer.Transform(in)
+
+					Convey("Then no item is sent to the next stage", func() {
+						So(next.ReadAll(), ShouldBeEmpty)
+					})
+				})
+			})
+		})
+	})
+}